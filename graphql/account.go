@@ -24,15 +24,18 @@ func (r *accountResolver) Orders(ctx context.Context, obj *Account) ([]*Order, e
 				ID:          p.ID,
 				Name:        p.Name,
 				Description: p.Description,
-				Price:       p.Price,
+				Price:       &p.Price,
 				Quantity:    int(p.Quantity),
 			})
 		}
 		orders = append(orders, &Order{
 			ID:         o.ID,
 			CreatedAt:  o.CreatedAt,
-			TotalPrice: o.TotalPrice,
+			TotalPrice: &o.TotalPrice,
 			Products:   products,
+
+			SourceCurrency: o.SourceCurrency,
+			ExchangeRate:   o.ExchangeRate,
 		})
 	}
 
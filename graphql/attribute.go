@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+func newProductAttributes(attributes []product.ProductAttribute) []*ProductAttribute {
+	result := []*ProductAttribute{}
+	for _, a := range attributes {
+		attribute := &ProductAttribute{Name: a.Name, Value: a.Value}
+		if a.Unit != "" {
+			attribute.Unit = &a.Unit
+		}
+		result = append(result, attribute)
+	}
+	return result
+}
+
+func productAttributes(input []*ProductAttributeInput) []product.ProductAttribute {
+	var result []product.ProductAttribute
+	for _, a := range input {
+		result = append(result, product.ProductAttribute{Name: a.Name, Value: a.Value})
+	}
+	return result
+}
+
+func attributeFilters(input []*AttributeFilterInput) []product.AttributeFilter {
+	var result []product.AttributeFilter
+	for _, f := range input {
+		result = append(result, product.AttributeFilter{
+			Name:   f.Name,
+			Values: f.Values,
+			Min:    f.Min,
+			Max:    f.Max,
+		})
+	}
+	return result
+}
+
+func categorySchema(input CategorySchemaInput) product.CategorySchema {
+	schema := product.CategorySchema{Category: input.Category}
+	for _, a := range input.Attributes {
+		schema.Attributes = append(schema.Attributes, product.AttributeDefinition{
+			Name:          a.Name,
+			Label:         stringValue(a.Label),
+			Type:          strings.ToLower(a.Type.String()),
+			Unit:          stringValue(a.Unit),
+			AllowedValues: a.AllowedValues,
+			Required:      a.Required != nil && *a.Required,
+		})
+	}
+	return schema
+}
+
+func newCategorySchema(s *product.CategorySchema) *CategorySchema {
+	result := &CategorySchema{
+		Category:   s.Category,
+		Attributes: []*AttributeDefinition{},
+	}
+	if !s.UpdatedAt.IsZero() {
+		result.UpdatedAt = &s.UpdatedAt
+	}
+
+	for _, a := range s.Attributes {
+		definition := &AttributeDefinition{
+			Name:          a.Name,
+			Label:         a.Label,
+			Type:          AttributeType(strings.ToUpper(a.Type)),
+			AllowedValues: []string{},
+			Required:      a.Required,
+		}
+		if a.Unit != "" {
+			definition.Unit = &a.Unit
+		}
+		definition.AllowedValues = append(definition.AllowedValues, a.AllowedValues...)
+		result.Attributes = append(result.Attributes, definition)
+	}
+	return result
+}
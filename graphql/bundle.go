@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+func (r *mutationResolver) SetProductBundle(ctx context.Context, productID string, components []*BundleComponentInput) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	var bundle []product.BundleComponent
+	for _, c := range components {
+		if c.Quantity <= 0 {
+			return nil, ErrInvalidParameter
+		}
+		bundle = append(bundle, product.BundleComponent{ProductID: c.ProductID, Quantity: uint32(c.Quantity)})
+	}
+
+	p, err := r.server.productClient.SetBundle(ctx, productID, accountId, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) SetProductStock(ctx context.Context, productID string, stock *int) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	var units *int64
+	if stock != nil {
+		n := int64(*stock)
+		units = &n
+	}
+
+	p, err := r.server.productClient.SetStock(ctx, productID, accountId, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/middleware"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+)
+
+// currencyHeader selects the display currency when a query does not pass one
+const currencyHeader = "X-Currency"
+
+// exchangeRatesTTL is how long rates fetched from the product service are
+// reused for display conversions
+const exchangeRatesTTL = time.Minute
+
+type exchangeRatesCache struct {
+	mu        sync.Mutex
+	rates     *money.ExchangeRates
+	fetchedAt time.Time
+}
+
+// requestedCurrency returns the normalized currency passed as an argument,
+// falling back to the X-Currency header. It is empty when neither is set.
+func requestedCurrency(ctx context.Context, currency *string) (string, error) {
+	code := ""
+	if currency != nil {
+		code = *currency
+	} else if ginContext, ok := ctx.Value(middleware.GinContextKey).(*gin.Context); ok {
+		code = ginContext.GetHeader(currencyHeader)
+	}
+	if code == "" {
+		return "", nil
+	}
+	return money.NormalizeCurrency(code)
+}
+
+func (s *Server) exchangeRates(ctx context.Context) (*money.ExchangeRates, error) {
+	s.rates.mu.Lock()
+	defer s.rates.mu.Unlock()
+
+	if s.rates.rates != nil && time.Since(s.rates.fetchedAt) < exchangeRatesTTL {
+		return s.rates.rates, nil
+	}
+
+	rates, err := s.productClient.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.rates.rates = rates
+	s.rates.fetchedAt = time.Now()
+	return rates, nil
+}
+
+func (s *Server) setExchangeRates(rates *money.ExchangeRates) {
+	s.rates.mu.Lock()
+	defer s.rates.mu.Unlock()
+
+	s.rates.rates = rates
+	s.rates.fetchedAt = time.Now()
+}
+
+// convertProducts shows the prices of products in currency. Conversions use
+// the same rounding as orders, so a product costs what it is shown at.
+func (s *Server) convertProducts(ctx context.Context, products []*Product, currency string) error {
+	if currency == "" || len(products) == 0 {
+		return nil
+	}
+
+	rates, err := s.exchangeRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	convert := func(m *money.Money) (*money.Money, error) {
+		if m == nil || m.Currency == currency {
+			return m, nil
+		}
+		converted, _, err := rates.Convert(*m, currency)
+		return &converted, err
+	}
+
+	for _, p := range products {
+		if p.Price, err = convert(p.Price); err != nil {
+			return err
+		}
+		if p.CompareAtPrice, err = convert(p.CompareAtPrice); err != nil {
+			return err
+		}
+		for _, schedule := range p.PriceSchedules {
+			if schedule.Price, err = convert(schedule.Price); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func newExchangeRates(r *money.ExchangeRates) *ExchangeRates {
+	result := &ExchangeRates{
+		Base:  r.Base,
+		Rates: []*ExchangeRate{},
+	}
+	if !r.UpdatedAt.IsZero() {
+		result.UpdatedAt = &r.UpdatedAt
+	}
+
+	for currency, rate := range r.Rates {
+		result.Rates = append(result.Rates, &ExchangeRate{Currency: currency, Rate: rate})
+	}
+	sort.Slice(result.Rates, func(i, j int) bool {
+		return result.Rates[i].Currency < result.Rates[j].Currency
+	})
+	return result
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -22,6 +23,8 @@ type Server struct {
 	productClient     *product.Client
 	orderClient       *order.Client
 	recommenderClient *recommender.Client
+	adminAccountIDs   map[string]bool
+	rates             exchangeRatesCache
 }
 
 func NewGraphQLServer(
@@ -29,6 +32,7 @@ func NewGraphQLServer(
 	productServiceURL,
 	orderServiceURL,
 	recommenderServiceURL string,
+	adminAccountIDs []string,
 ) (*Server, error) {
 	// Connect to account service
 	accountClient, err := account.NewClient(accountServiceURL)
@@ -54,14 +58,25 @@ func NewGraphQLServer(
 		return nil, err
 	}
 
+	admins := make(map[string]bool, len(adminAccountIDs))
+	for _, id := range adminAccountIDs {
+		admins[id] = true
+	}
+
 	return &Server{
 		accountClient:     accountClient,
 		productClient:     productClient,
 		orderClient:       orderClient,
 		recommenderClient: recommenderClient,
+		adminAccountIDs:   admins,
 	}, nil
 }
 
+func (s *Server) isAdmin(ctx context.Context) bool {
+	accountId := account.GetUserId(ctx)
+	return accountId != "" && s.adminAccountIDs[accountId]
+}
+
 func (s *Server) Mutation() MutationResolver {
 	return &mutationResolver{server: s}
 }
@@ -74,6 +89,10 @@ func (s *Server) Account() AccountResolver {
 	return &accountResolver{server: s}
 }
 
+func (s *Server) Product() ProductResolver {
+	return &productResolver{server: s}
+}
+
 func (s *Server) toExecutableSchema() graphql.ExecutableSchema {
 	return NewExecutableSchema(Config{
 		Resolvers: s,
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+func (r *queryResolver) ProductHistory(ctx context.Context, id string, pagination *PaginationInput) ([]*ProductVersion, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	if !r.server.isAdmin(ctx) {
+		products, err := r.server.productClient.GetProducts(ctx, 0, 0, []string{id}, "")
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		if len(products) == 0 {
+			return nil, product.ErrNotFound
+		}
+		if products[0].AccountID != accountId {
+			return nil, ErrForbidden
+		}
+	}
+
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	versions, err := r.server.productClient.GetProductHistory(ctx, id, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*ProductVersion{}
+	for _, v := range versions {
+		version := &ProductVersion{
+			Version:   v.Version,
+			ChangedAt: v.ChangedAt,
+			Changes:   []*FieldChange{},
+		}
+		if v.ChangedBy != "" {
+			version.ChangedBy = &v.ChangedBy
+		}
+		for _, c := range v.Changes {
+			change := &FieldChange{Field: c.Field}
+			if c.From != "" {
+				change.From = &c.From
+			}
+			if c.To != "" {
+				change.To = &c.To
+			}
+			version.Changes = append(version.Changes, change)
+		}
+		result = append(result, version)
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) RevertProduct(ctx context.Context, id string, version string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.RevertProduct(ctx, id, accountId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
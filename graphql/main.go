@@ -17,13 +17,14 @@ import (
 )
 
 type AppConfig struct {
-	AccountServiceURL     string `envconfig:"ACCOUNT_SERVICE_URL"`
-	ProductServiceURL     string `envconfig:"PRODUCT_SERVICE_URL"`
-	OrderServiceURL       string `envconfig:"ORDER_SERVICE_URL"`
-	RecommenderServiceURL string `envconfig:"RECOMMENDER_SERVICE_URL"`
-	Port                  string `envconfig:"PORT"`
-	SecretKey             string `envconfig:"SECRET_KEY"`
-	Issuer                string `envconfig:"ISSUER"`
+	AccountServiceURL     string   `envconfig:"ACCOUNT_SERVICE_URL"`
+	ProductServiceURL     string   `envconfig:"PRODUCT_SERVICE_URL"`
+	OrderServiceURL       string   `envconfig:"ORDER_SERVICE_URL"`
+	RecommenderServiceURL string   `envconfig:"RECOMMENDER_SERVICE_URL"`
+	Port                  string   `envconfig:"PORT"`
+	SecretKey             string   `envconfig:"SECRET_KEY"`
+	Issuer                string   `envconfig:"ISSUER"`
+	AdminAccountIDs       []string `envconfig:"ADMIN_ACCOUNT_IDS"`
 }
 
 func main() {
@@ -32,7 +33,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	s, err := NewGraphQLServer(cfg.AccountServiceURL, cfg.ProductServiceURL, cfg.OrderServiceURL, cfg.RecommenderServiceURL)
+	s, err := NewGraphQLServer(cfg.AccountServiceURL, cfg.ProductServiceURL, cfg.OrderServiceURL, cfg.RecommenderServiceURL, cfg.AdminAccountIDs)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -42,6 +43,10 @@ func main() {
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: 10 << 20,
+		MaxMemory:     10 << 20,
+	})
 
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 
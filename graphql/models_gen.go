@@ -3,17 +3,103 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 )
 
+type Answer struct {
+	ID               string       `json:"id"`
+	AccountID        string       `json:"accountId"`
+	Body             string       `json:"body"`
+	FromSeller       bool         `json:"fromSeller"`
+	VerifiedPurchase bool         `json:"verifiedPurchase"`
+	Status           ReviewStatus `json:"status"`
+	CreatedAt        time.Time    `json:"createdAt"`
+}
+
+type AttributeDefinition struct {
+	Name          string        `json:"name"`
+	Label         string        `json:"label"`
+	Type          AttributeType `json:"type"`
+	Unit          *string       `json:"unit,omitempty"`
+	AllowedValues []string      `json:"allowedValues"`
+	Required      bool          `json:"required"`
+}
+
+type AttributeDefinitionInput struct {
+	Name          string        `json:"name"`
+	Label         *string       `json:"label,omitempty"`
+	Type          AttributeType `json:"type"`
+	Unit          *string       `json:"unit,omitempty"`
+	AllowedValues []string      `json:"allowedValues,omitempty"`
+	Required      *bool         `json:"required,omitempty"`
+}
+
+type AttributeFilterInput struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values,omitempty"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+}
+
 type AuthResponse struct {
 	Token string `json:"token"`
 }
 
+type BundleComponent struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type BundleComponentInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type CategorySchema struct {
+	Category   string                 `json:"category"`
+	Attributes []*AttributeDefinition `json:"attributes"`
+	UpdatedAt  *time.Time             `json:"updatedAt,omitempty"`
+}
+
+type CategorySchemaInput struct {
+	Category   string                      `json:"category"`
+	Attributes []*AttributeDefinitionInput `json:"attributes"`
+}
+
 type CreateProductInput struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Category    *string                  `json:"category,omitempty"`
+	Price       *money.Money             `json:"price"`
+	Attributes  []*ProductAttributeInput `json:"attributes,omitempty"`
+}
+
+type ExchangeRate struct {
+	Currency string `json:"currency"`
+	Rate     string `json:"rate"`
+}
+
+type ExchangeRateInput struct {
+	Currency string `json:"currency"`
+	Rate     string `json:"rate"`
+}
+
+type ExchangeRates struct {
+	Base      string          `json:"base"`
+	Rates     []*ExchangeRate `json:"rates"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
+}
+
+type FieldChange struct {
+	Field string  `json:"field"`
+	From  *string `json:"from,omitempty"`
+	To    *string `json:"to,omitempty"`
 }
 
 type LoginInput struct {
@@ -25,22 +111,25 @@ type Mutation struct {
 }
 
 type Order struct {
-	ID         string            `json:"id"`
-	CreatedAt  time.Time         `json:"createdAt"`
-	TotalPrice float64           `json:"totalPrice"`
-	Products   []*OrderedProduct `json:"products"`
+	ID             string            `json:"id"`
+	CreatedAt      time.Time         `json:"createdAt"`
+	TotalPrice     *money.Money      `json:"totalPrice"`
+	Products       []*OrderedProduct `json:"products"`
+	SourceCurrency string            `json:"sourceCurrency"`
+	ExchangeRate   string            `json:"exchangeRate"`
 }
 
 type OrderInput struct {
 	Products []*OrderedProductInput `json:"products"`
+	Currency *string                `json:"currency,omitempty"`
 }
 
 type OrderedProduct struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Price       *money.Money `json:"price"`
+	Quantity    int          `json:"quantity"`
 }
 
 type OrderedProductInput struct {
@@ -48,31 +137,487 @@ type OrderedProductInput struct {
 	Quantity int    `json:"quantity"`
 }
 
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
+
 type PaginationInput struct {
 	Skip int `json:"skip"`
 	Take int `json:"take"`
 }
 
+type PricePoint struct {
+	Price          *money.Money `json:"price"`
+	CompareAtPrice *money.Money `json:"compareAtPrice,omitempty"`
+	Reason         string       `json:"reason"`
+	ChangedAt      time.Time    `json:"changedAt"`
+}
+
+type PriceSchedule struct {
+	ID       string       `json:"id"`
+	Price    *money.Money `json:"price"`
+	StartsAt time.Time    `json:"startsAt"`
+	EndsAt   time.Time    `json:"endsAt"`
+}
+
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	AccountID   string  `json:"accountId"`
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	Description    string              `json:"description"`
+	Category       string              `json:"category"`
+	Price          *money.Money        `json:"price"`
+	BasePrice      *money.Money        `json:"basePrice"`
+	AccountID      string              `json:"accountId"`
+	Images         []*ProductImage     `json:"images"`
+	Version        string              `json:"version"`
+	ArchivedAt     *time.Time          `json:"archivedAt,omitempty"`
+	Rating         float64             `json:"rating"`
+	ReviewCount    int                 `json:"reviewCount"`
+	Reviews        []*Review           `json:"reviews"`
+	Questions      []*Question         `json:"questions"`
+	Related        []*Product          `json:"related"`
+	CompareAtPrice *money.Money        `json:"compareAtPrice,omitempty"`
+	PriceSchedules []*PriceSchedule    `json:"priceSchedules"`
+	PriceHistory   []*PricePoint       `json:"priceHistory"`
+	Attributes     []*ProductAttribute `json:"attributes"`
+	Status         ProductStatus       `json:"status"`
+	PublishAt      *time.Time          `json:"publishAt,omitempty"`
+	Stock          *int                `json:"stock,omitempty"`
+	Bundle         []*BundleComponent  `json:"bundle"`
+	Screening      *ProductScreening   `json:"screening,omitempty"`
+}
+
+type ProductAttribute struct {
+	Name  string  `json:"name"`
+	Value string  `json:"value"`
+	Unit  *string `json:"unit,omitempty"`
+}
+
+type ProductAttributeInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ProductConnection struct {
+	Edges    []*ProductEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type ProductEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Product `json:"node"`
+}
+
+type ProductImage struct {
+	ID         string       `json:"id"`
+	URL        string       `json:"url"`
+	Width      int          `json:"width"`
+	Height     int          `json:"height"`
+	Position   int          `json:"position"`
+	Thumbnails []*Thumbnail `json:"thumbnails"`
+}
+
+type ProductScreening struct {
+	Status     ScreeningStatus `json:"status"`
+	Reasons    []string        `json:"reasons"`
+	FlaggedAt  time.Time       `json:"flaggedAt"`
+	Note       *string         `json:"note,omitempty"`
+	ReviewedAt *time.Time      `json:"reviewedAt,omitempty"`
+}
+
+type ProductSuggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProductSuggestions struct {
+	Products   []*ProductSuggestion `json:"products"`
+	Categories []string             `json:"categories"`
+}
+
+type ProductVersion struct {
+	Version   string         `json:"version"`
+	ChangedBy *string        `json:"changedBy,omitempty"`
+	ChangedAt time.Time      `json:"changedAt"`
+	Changes   []*FieldChange `json:"changes"`
 }
 
 type Query struct {
 }
 
+type Question struct {
+	ID        string       `json:"id"`
+	ProductID string       `json:"productId"`
+	AccountID string       `json:"accountId"`
+	Body      string       `json:"body"`
+	Status    ReviewStatus `json:"status"`
+	Answers   []*Answer    `json:"answers"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
 type RegisterInput struct {
 	Name     string `json:"name"`
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
 
+type Review struct {
+	ID               string       `json:"id"`
+	ProductID        string       `json:"productId"`
+	AccountID        string       `json:"accountId"`
+	Rating           int          `json:"rating"`
+	Title            string       `json:"title"`
+	Body             string       `json:"body"`
+	VerifiedPurchase bool         `json:"verifiedPurchase"`
+	Status           ReviewStatus `json:"status"`
+	Reply            *ReviewReply `json:"reply,omitempty"`
+	HelpfulVotes     int          `json:"helpfulVotes"`
+	CreatedAt        time.Time    `json:"createdAt"`
+}
+
+type ReviewInput struct {
+	ProductID string `json:"productId"`
+	Rating    int    `json:"rating"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+}
+
+type ReviewReply struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ScreeningRules struct {
+	BannedTerms      []string     `json:"bannedTerms"`
+	MinPrice         *money.Money `json:"minPrice,omitempty"`
+	MaxPrice         *money.Money `json:"maxPrice,omitempty"`
+	DetectDuplicates bool         `json:"detectDuplicates"`
+	UpdatedAt        *time.Time   `json:"updatedAt,omitempty"`
+}
+
+type ScreeningRulesInput struct {
+	BannedTerms      []string     `json:"bannedTerms"`
+	MinPrice         *money.Money `json:"minPrice,omitempty"`
+	MaxPrice         *money.Money `json:"maxPrice,omitempty"`
+	DetectDuplicates bool         `json:"detectDuplicates"`
+}
+
+type Thumbnail struct {
+	Size   string `json:"size"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 type UpdateProductInput struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID              string                   `json:"id"`
+	Name            *string                  `json:"name,omitempty"`
+	Description     *string                  `json:"description,omitempty"`
+	Category        *string                  `json:"category,omitempty"`
+	Price           *money.Money             `json:"price,omitempty"`
+	Attributes      []*ProductAttributeInput `json:"attributes,omitempty"`
+	ExpectedVersion *string                  `json:"expectedVersion,omitempty"`
+}
+
+type Wishlist struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	IsDefault  bool            `json:"isDefault"`
+	ShareToken *string         `json:"shareToken,omitempty"`
+	Items      []*WishlistItem `json:"items"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+type WishlistItem struct {
+	ProductID string    `json:"productId"`
+	Product   *Product  `json:"product,omitempty"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "TEXT"
+	AttributeTypeNumber  AttributeType = "NUMBER"
+	AttributeTypeBoolean AttributeType = "BOOLEAN"
+	AttributeTypeEnum    AttributeType = "ENUM"
+)
+
+var AllAttributeType = []AttributeType{
+	AttributeTypeText,
+	AttributeTypeNumber,
+	AttributeTypeBoolean,
+	AttributeTypeEnum,
+}
+
+func (e AttributeType) IsValid() bool {
+	switch e {
+	case AttributeTypeText, AttributeTypeNumber, AttributeTypeBoolean, AttributeTypeEnum:
+		return true
+	}
+	return false
+}
+
+func (e AttributeType) String() string {
+	return string(e)
+}
+
+func (e *AttributeType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = AttributeType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid AttributeType", str)
+	}
+	return nil
+}
+
+func (e AttributeType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *AttributeType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e AttributeType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProductSort string
+
+const (
+	ProductSortRelevance   ProductSort = "RELEVANCE"
+	ProductSortRating      ProductSort = "RATING"
+	ProductSortBestSelling ProductSort = "BEST_SELLING"
+)
+
+var AllProductSort = []ProductSort{
+	ProductSortRelevance,
+	ProductSortRating,
+	ProductSortBestSelling,
+}
+
+func (e ProductSort) IsValid() bool {
+	switch e {
+	case ProductSortRelevance, ProductSortRating, ProductSortBestSelling:
+		return true
+	}
+	return false
+}
+
+func (e ProductSort) String() string {
+	return string(e)
+}
+
+func (e *ProductSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductSort", str)
+	}
+	return nil
+}
+
+func (e ProductSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProductStatus string
+
+const (
+	ProductStatusDraft         ProductStatus = "DRAFT"
+	ProductStatusPendingReview ProductStatus = "PENDING_REVIEW"
+	ProductStatusPublished     ProductStatus = "PUBLISHED"
+	ProductStatusUnpublished   ProductStatus = "UNPUBLISHED"
+)
+
+var AllProductStatus = []ProductStatus{
+	ProductStatusDraft,
+	ProductStatusPendingReview,
+	ProductStatusPublished,
+	ProductStatusUnpublished,
+}
+
+func (e ProductStatus) IsValid() bool {
+	switch e {
+	case ProductStatusDraft, ProductStatusPendingReview, ProductStatusPublished, ProductStatusUnpublished:
+		return true
+	}
+	return false
+}
+
+func (e ProductStatus) String() string {
+	return string(e)
+}
+
+func (e *ProductStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductStatus", str)
+	}
+	return nil
+}
+
+func (e ProductStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReviewStatus string
+
+const (
+	ReviewStatusApproved ReviewStatus = "APPROVED"
+	ReviewStatusRejected ReviewStatus = "REJECTED"
+)
+
+var AllReviewStatus = []ReviewStatus{
+	ReviewStatusApproved,
+	ReviewStatusRejected,
+}
+
+func (e ReviewStatus) IsValid() bool {
+	switch e {
+	case ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e ReviewStatus) String() string {
+	return string(e)
+}
+
+func (e *ReviewStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReviewStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReviewStatus", str)
+	}
+	return nil
+}
+
+func (e ReviewStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReviewStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReviewStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ScreeningStatus string
+
+const (
+	ScreeningStatusFlagged  ScreeningStatus = "FLAGGED"
+	ScreeningStatusApproved ScreeningStatus = "APPROVED"
+	ScreeningStatusRejected ScreeningStatus = "REJECTED"
+)
+
+var AllScreeningStatus = []ScreeningStatus{
+	ScreeningStatusFlagged,
+	ScreeningStatusApproved,
+	ScreeningStatusRejected,
+}
+
+func (e ScreeningStatus) IsValid() bool {
+	switch e {
+	case ScreeningStatusFlagged, ScreeningStatusApproved, ScreeningStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e ScreeningStatus) String() string {
+	return string(e)
+}
+
+func (e *ScreeningStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScreeningStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScreeningStatus", str)
+	}
+	return nil
+}
+
+func (e ScreeningStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ScreeningStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ScreeningStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
@@ -4,13 +4,20 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/gin-gonic/gin"
 	"github.com/go-systems-lab/go-ecommerce-lld/account"
 	"github.com/go-systems-lab/go-ecommerce-lld/order"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 )
 
-var ErrInvalidParameter = errors.New("invalid parameter")
+var (
+	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrForbidden        = errors.New("forbidden")
+)
 
 type mutationResolver struct {
 	server *Server
@@ -57,20 +64,14 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, product CreateProd
 	}
 
 	log.Println("Calling productClient.PostProduct with accountId:", accountId)
-	createdProduct, err := r.server.productClient.PostProduct(ctx, product.Name, product.Description, product.Price, accountId)
+	createdProduct, err := r.server.productClient.PostProduct(ctx, product.Name, product.Description, stringValue(product.Category), *product.Price, productAttributes(product.Attributes), accountId)
 	if err != nil {
 		log.Println("Error from productClient.PostProduct:", err)
 		return nil, err
 	}
 
 	log.Println("Successfully created product:", createdProduct)
-	return &Product{
-		ID:          createdProduct.ID,
-		Name:        createdProduct.Name,
-		Description: createdProduct.Description,
-		Price:       createdProduct.Price,
-		AccountID:   accountId,
-	}, nil
+	return newProduct(createdProduct), nil
 }
 
 func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Order, error) {
@@ -93,8 +94,13 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 		return nil, errors.New("unauthorized")
 	}
 
+	currency, err := requestedCurrency(ctx, in.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Printf("Calling orderClient.PostOrder with accountId=%s and %d products", accountId, len(products))
-	o, err := r.server.orderClient.PostOrder(ctx, accountId, products)
+	o, err := r.server.orderClient.PostOrder(ctx, accountId, currency, products)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -107,7 +113,7 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 			ID:          p.ID,
 			Name:        p.Name,
 			Description: p.Description,
-			Price:       p.Price,
+			Price:       &p.Price,
 			Quantity:    int(p.Quantity),
 		})
 	}
@@ -115,8 +121,11 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 	return &Order{
 		ID:         o.ID,
 		CreatedAt:  o.CreatedAt,
-		TotalPrice: o.TotalPrice,
+		TotalPrice: &o.TotalPrice,
 		Products:   orderProducts,
+
+		SourceCurrency: o.SourceCurrency,
+		ExchangeRate:   o.ExchangeRate,
 	}, nil
 }
 
@@ -126,18 +135,34 @@ func (r *mutationResolver) UpdateProduct(ctx context.Context, product UpdateProd
 		return nil, errors.New("unauthorized")
 	}
 
-	updatedProduct, err := r.server.productClient.UpdateProduct(ctx, product.ID, product.Name, product.Description, product.Price, accountId)
+	var paths []string
+	var price money.Money
+	if product.Name != nil {
+		paths = append(paths, "name")
+	}
+	if product.Description != nil {
+		paths = append(paths, "description")
+	}
+	if product.Category != nil {
+		paths = append(paths, "category")
+	}
+	if product.Price != nil {
+		paths = append(paths, "price")
+		price = *product.Price
+	}
+	if product.Attributes != nil {
+		paths = append(paths, "attributes")
+	}
+	if len(paths) == 0 {
+		return nil, ErrInvalidParameter
+	}
+
+	updatedProduct, err := r.server.productClient.UpdateProduct(ctx, product.ID, stringValue(product.Name), stringValue(product.Description), stringValue(product.Category), price, productAttributes(product.Attributes), accountId, stringValue(product.ExpectedVersion), paths)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Product{
-		ID:          updatedProduct.ID,
-		Name:        updatedProduct.Name,
-		Description: updatedProduct.Description,
-		Price:       updatedProduct.Price,
-		AccountID:   accountId,
-	}, nil
+	return newProduct(updatedProduct), nil
 }
 
 func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (*bool, error) {
@@ -156,3 +181,252 @@ func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (*bool,
 	result := true
 	return &result, nil
 }
+
+func (r *mutationResolver) RestoreProduct(ctx context.Context, id string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	restoredProduct, err := r.server.productClient.RestoreProduct(ctx, id, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(restoredProduct), nil
+}
+
+func (r *mutationResolver) PublishProduct(ctx context.Context, id string, publishAt *time.Time) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.PublishProduct(ctx, id, accountId, publishAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) UnpublishProduct(ctx context.Context, id string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.UnpublishProduct(ctx, id, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) ReviewProduct(ctx context.Context, id string, approve bool) (*Product, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	p, err := r.server.productClient.ReviewProduct(ctx, id, account.GetUserId(ctx), approve)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) UploadProductImage(ctx context.Context, productID string, file graphql.Upload) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	log.Printf("Uploading image %s (%d bytes) for product %s", file.Filename, file.Size, productID)
+
+	p, err := r.server.productClient.UploadProductImage(ctx, productID, accountId, file.Filename, file.File)
+	if err != nil {
+		log.Println("Error from productClient.UploadProductImage:", err)
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) RemoveProductImage(ctx context.Context, productID string, imageID string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.RemoveProductImage(ctx, productID, imageID, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) ReorderProductImages(ctx context.Context, productID string, imageIds []string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.ReorderProductImages(ctx, productID, accountId, imageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) UpdateSearchSynonyms(ctx context.Context, rules []string) ([]string, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	return r.server.productClient.UpdateSearchSynonyms(ctx, rules)
+}
+
+func (r *mutationResolver) UpdateExchangeRates(ctx context.Context, base string, rates []*ExchangeRateInput) (*ExchangeRates, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	input := money.ExchangeRates{Base: base, Rates: make(map[string]string, len(rates))}
+	for _, rate := range rates {
+		input.Rates[rate.Currency] = rate.Rate
+	}
+
+	updated, err := r.server.productClient.UpdateExchangeRates(ctx, input)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	r.server.setExchangeRates(updated)
+
+	return newExchangeRates(updated), nil
+}
+
+func (r *mutationResolver) UpdateCategorySchema(ctx context.Context, schema CategorySchemaInput) (*CategorySchema, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	updated, err := r.server.productClient.UpdateCategorySchema(ctx, categorySchema(schema))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newCategorySchema(updated), nil
+}
+
+func (r *mutationResolver) SchedulePrice(ctx context.Context, productID string, price money.Money, startsAt time.Time, endsAt time.Time) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.SchedulePrice(ctx, productID, accountId, price, startsAt, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) CancelPriceSchedule(ctx context.Context, productID string, scheduleID string) (*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	p, err := r.server.productClient.CancelPriceSchedule(ctx, productID, scheduleID, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) PostReview(ctx context.Context, review ReviewInput) (*Review, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	verified, err := r.hasPurchased(ctx, accountId, review.ProductID)
+	if err != nil {
+		log.Printf("Error checking purchase history for account %s: %v", accountId, err)
+		return nil, err
+	}
+
+	postedReview, err := r.server.productClient.PostReview(ctx, review.ProductID, accountId, review.Rating, review.Title, review.Body, verified)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReview(postedReview), nil
+}
+
+// hasPurchased reports whether any of the account's orders contains the product
+func (r *mutationResolver) hasPurchased(ctx context.Context, accountId, productId string) (bool, error) {
+	orders, err := r.server.orderClient.GetOrdersForAccount(ctx, accountId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, o := range orders {
+		for _, p := range o.Products {
+			if p.ID == productId {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *mutationResolver) ReplyToReview(ctx context.Context, reviewID string, body string) (*Review, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	review, err := r.server.productClient.ReplyToReview(ctx, reviewID, accountId, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReview(review), nil
+}
+
+func (r *mutationResolver) VoteReviewHelpful(ctx context.Context, reviewID string) (*Review, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	review, err := r.server.productClient.VoteReviewHelpful(ctx, reviewID, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newReview(review), nil
+}
+
+func (r *mutationResolver) ModerateReview(ctx context.Context, reviewID string, status ReviewStatus) (*Review, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	review, err := r.server.productClient.ModerateReview(ctx, reviewID, strings.ToLower(string(status)))
+	if err != nil {
+		return nil, err
+	}
+
+	return newReview(review), nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+type productResolver struct {
+	server *Server
+}
+
+func (r *productResolver) Reviews(ctx context.Context, obj *Product, pagination *PaginationInput) ([]*Review, error) {
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	reviews, err := r.server.productClient.GetProductReviews(ctx, obj.ID, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*Review{}
+	for _, review := range reviews {
+		result = append(result, newReview(&review))
+	}
+	return result, nil
+}
+
+// Related prefers the recommender, which learns from what shoppers viewed
+// together, and falls back to products with similar text when it has nothing
+// on obj, as for new products
+func (r *productResolver) Related(ctx context.Context, obj *Product, take *int) ([]*Product, error) {
+	size := 10
+	if take != nil && *take > 0 {
+		size = *take
+	}
+
+	products, err := r.recommended(ctx, obj.ID, size)
+	if err != nil {
+		log.Printf("Error getting recommendations for product %s: %v", obj.ID, err)
+	}
+	if len(products) == 0 {
+		products, err = r.server.productClient.GetRelatedProducts(ctx, obj.ID, size)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+	}
+
+	result := []*Product{}
+	for _, p := range products {
+		result = append(result, newProduct(&p))
+	}
+
+	currency, err := requestedCurrency(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.server.convertProducts(ctx, result, currency); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// recommended returns the published products the recommender suggests to
+// viewers of productId, in its order
+func (r *productResolver) recommended(ctx context.Context, productId string, take int) ([]product.Product, error) {
+	res, err := r.server.recommenderClient.GetRecommendationOnViews(ctx, []string{productId}, 0, uint64(take))
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, p := range res.GetRecommendedProducts() {
+		if p.GetId() != productId {
+			ids = append(ids, p.GetId())
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	found, err := r.server.productClient.GetProducts(ctx, 0, 0, ids, "")
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]product.Product{}
+	for _, p := range found {
+		byID[p.ID] = p
+	}
+
+	now := time.Now()
+	var products []product.Product
+	for _, id := range ids {
+		if p, ok := byID[id]; ok && p.IsPublished(now) {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func newProduct(p *product.Product) *Product {
+	result := &Product{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Category:    p.Category,
+		Price:       &p.Price,
+		BasePrice:   &p.Price,
+		AccountID:   p.AccountID,
+		Images:      []*ProductImage{},
+		Version:     p.Version,
+		ArchivedAt:  p.ArchivedAt,
+		Rating:      p.Rating,
+		ReviewCount: p.ReviewCount,
+
+		PriceSchedules: []*PriceSchedule{},
+		Attributes:     newProductAttributes(p.Attributes),
+		Status:         ProductStatus(strings.ToUpper(p.Status)),
+		PublishAt:      p.PublishAt,
+		Bundle:         []*BundleComponent{},
+	}
+	if p.Stock != nil {
+		stock := int(*p.Stock)
+		result.Stock = &stock
+	}
+	for _, c := range p.Bundle {
+		result.Bundle = append(result.Bundle, &BundleComponent{ProductID: c.ProductID, Quantity: int(c.Quantity)})
+	}
+	if p.Screening != nil {
+		result.Screening = newProductScreening(p.Screening)
+	}
+	if compareAt := p.CompareAtPrice(); !compareAt.IsZero() {
+		result.CompareAtPrice = &compareAt
+	}
+
+	for _, schedule := range p.PriceSchedules {
+		result.PriceSchedules = append(result.PriceSchedules, &PriceSchedule{
+			ID:       schedule.ID,
+			Price:    &schedule.Price,
+			StartsAt: schedule.StartsAt,
+			EndsAt:   schedule.EndsAt,
+		})
+	}
+
+	for _, img := range p.Images {
+		image := &ProductImage{
+			ID:         img.ID,
+			URL:        img.URL,
+			Width:      img.Width,
+			Height:     img.Height,
+			Position:   img.Position,
+			Thumbnails: []*Thumbnail{},
+		}
+		for _, t := range img.Thumbnails {
+			image.Thumbnails = append(image.Thumbnails, &Thumbnail{
+				Size:   t.Size,
+				URL:    t.URL,
+				Width:  t.Width,
+				Height: t.Height,
+			})
+		}
+		result.Images = append(result.Images, image)
+	}
+
+	return result
+}
+
+func (r *productResolver) PriceHistory(ctx context.Context, obj *Product, pagination *PaginationInput) ([]*PricePoint, error) {
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	points, err := r.server.productClient.GetPriceHistory(ctx, obj.ID, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*PricePoint{}
+	for _, point := range points {
+		pp := &PricePoint{
+			Price:     &point.Price,
+			Reason:    point.Reason,
+			ChangedAt: point.ChangedAt,
+		}
+		if !point.CompareAtPrice.IsZero() {
+			pp.CompareAtPrice = &point.CompareAtPrice
+		}
+		result = append(result, pp)
+	}
+	return result, nil
+}
+
+func newReview(r *product.Review) *Review {
+	review := &Review{
+		ID:               r.ID,
+		ProductID:        r.ProductID,
+		AccountID:        r.AccountID,
+		Rating:           r.Rating,
+		Title:            r.Title,
+		Body:             r.Body,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           ReviewStatus(strings.ToUpper(r.Status)),
+		HelpfulVotes:     r.HelpfulVotes,
+		CreatedAt:        r.CreatedAt,
+	}
+	if r.Reply != nil {
+		review.Reply = &ReviewReply{
+			Body:      r.Reply.Body,
+			CreatedAt: r.Reply.CreatedAt,
+		}
+	}
+	return review
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func productSort(sort *ProductSort) string {
+	if sort == nil {
+		return product.SortRelevance
+	}
+	switch *sort {
+	case ProductSortRating:
+		return product.SortRating
+	case ProductSortBestSelling:
+		return product.SortBestSelling
+	}
+	return product.SortRelevance
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
 )
 
 type queryResolver struct {
@@ -53,21 +57,36 @@ func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInpu
 	return result, nil
 }
 
-func (r *queryResolver) Product(ctx context.Context, pagination *PaginationInput, query, id *string, viewedProductIds []*string, byAccountId *bool) ([]*Product, error) {
+func (r *queryResolver) Product(ctx context.Context, pagination *PaginationInput, query, id *string, viewedProductIds []*string, byAccountId *bool, minRating *float64, sort *ProductSort, attributes []*AttributeFilterInput, currency *string) ([]*Product, error) {
+	displayCurrency, err := requestedCurrency(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := r.products(ctx, pagination, query, id, viewedProductIds, byAccountId, minRating, sort, attributes)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.server.convertProducts(ctx, products, displayCurrency); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *queryResolver) products(ctx context.Context, pagination *PaginationInput, query, id *string, viewedProductIds []*string, byAccountId *bool, minRating *float64, sort *ProductSort, attributes []*AttributeFilterInput) ([]*Product, error) {
 	if id != nil {
-		product, err := r.server.productClient.GetProduct(ctx, *id)
+		p, err := r.server.productClient.GetProduct(ctx, *id)
 		if err != nil {
 			return nil, err
 		}
-		return []*Product{
-			{
-				ID:          product.ID,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				AccountID:   product.AccountID,
-			},
-		}, nil
+		// Products shoppers cannot see are shown only to their seller and admins
+		accountId := account.GetUserId(ctx)
+		isSeller := accountId != "" && p.AccountID == accountId
+		if !p.IsPublished(time.Now()) && !isSeller && !r.server.isAdmin(ctx) {
+			return nil, product.ErrNotFound
+		}
+		return []*Product{newProduct(p)}, nil
 	}
 
 	skip, take := uint64(0), uint64(10)
@@ -94,38 +113,30 @@ func (r *queryResolver) Product(ctx context.Context, pagination *PaginationInput
 					ID:          product.Id,
 					Name:        product.Name,
 					Description: product.Description,
-					Price:       product.Price,
+					Price:       &money.Money{Amount: product.GetPrice().GetAmount(), Currency: product.GetPrice().GetCurrency()},
+					BasePrice:   &money.Money{Amount: product.GetPrice().GetAmount(), Currency: product.GetPrice().GetCurrency()},
 				},
 			)
 		}
 		return products, nil
 	}
 
+	// List the caller's own products, as myProducts does without filters
 	if byAccountId != nil && *byAccountId == true {
 		accountId := account.GetUserId(ctx)
 		if accountId == "" {
 			return nil, errors.New("account ID not found")
 		}
-		skip = 0
-		take = 100
-		res, err := r.server.recommenderClient.GetRecommendationForUserId(ctx, accountId, skip, take)
+		products, err := r.server.productClient.ListProductsByAccount(ctx, accountId, nil, nil, skip, take)
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		productList := res.GetRecommendedProducts()
-		var products []*Product
-		for _, product := range productList {
-			products = append(products,
-				&Product{
-					ID:          product.Id,
-					Name:        product.Name,
-					Description: product.Description,
-					Price:       product.Price,
-				},
-			)
+		var result []*Product
+		for _, p := range products {
+			result = append(result, newProduct(&p))
 		}
-		return products, nil
+		return result, nil
 	}
 
 	q := ""
@@ -133,7 +144,17 @@ func (r *queryResolver) Product(ctx context.Context, pagination *PaginationInput
 		q = *query
 	}
 
-	products, err := r.server.productClient.GetProducts(ctx, skip, take, nil, q)
+	var products []product.Product
+	var err error
+	if minRating != nil || sort != nil || len(attributes) > 0 {
+		rating := 0.0
+		if minRating != nil {
+			rating = *minRating
+		}
+		products, err = r.server.productClient.SearchProducts(ctx, q, skip, take, rating, productSort(sort), attributeFilters(attributes))
+	} else {
+		products, err = r.server.productClient.GetProducts(ctx, skip, take, nil, q)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -145,17 +166,151 @@ func (r *queryResolver) Product(ctx context.Context, pagination *PaginationInput
 
 	var result []*Product
 	for _, p := range products {
-		result = append(result, &Product{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			AccountID:   p.AccountID,
+		result = append(result, newProduct(&p))
+	}
+	return result, nil
+}
+
+// Products lists the catalog with cursors, which unlike pagination on
+// product can page past the first 10,000 results
+func (r *queryResolver) Products(ctx context.Context, first *int, after, query *string, minRating *float64, sort *ProductSort, attributes []*AttributeFilterInput, currency *string) (*ProductConnection, error) {
+	displayCurrency, err := requestedCurrency(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	take := uint64(10)
+	if first != nil {
+		if *first < 0 {
+			return nil, errors.New("first must not be negative")
+		}
+		take = uint64(*first)
+	}
+	q, cursor, rating := "", "", 0.0
+	if query != nil {
+		q = *query
+	}
+	if after != nil {
+		cursor = *after
+	}
+	if minRating != nil {
+		rating = *minRating
+	}
+	page, err := r.server.productClient.SearchProductsPage(ctx, q, rating, productSort(sort), attributeFilters(attributes), take, cursor)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	var nodes []*Product
+	for _, p := range page.Products {
+		nodes = append(nodes, newProduct(&p))
+	}
+	if err := r.server.convertProducts(ctx, nodes, displayCurrency); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := &ProductConnection{
+		Edges:    []*ProductEdge{},
+		PageInfo: &PageInfo{HasNextPage: page.HasMore},
+	}
+	for i, node := range nodes {
+		result.Edges = append(result.Edges, &ProductEdge{Cursor: page.Cursors[i], Node: node})
+	}
+	if len(page.Cursors) > 0 {
+		result.PageInfo.EndCursor = &page.Cursors[len(page.Cursors)-1]
+	}
+	return result, nil
+}
+
+func (r *queryResolver) MyProducts(ctx context.Context, pagination *PaginationInput, status []ProductStatus, archived *bool) ([]*Product, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	var statuses []string
+	for _, s := range status {
+		statuses = append(statuses, strings.ToLower(s.String()))
+	}
+
+	products, err := r.server.productClient.ListProductsByAccount(ctx, accountId, statuses, archived, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*Product{}
+	for _, p := range products {
+		result = append(result, newProduct(&p))
+	}
+	return result, nil
+}
+
+func (r *queryResolver) ProductSuggestions(ctx context.Context, prefix string, take *int) (*ProductSuggestions, error) {
+	size := 0
+	if take != nil {
+		size = *take
+	}
+
+	suggestions, err := r.server.productClient.SuggestProducts(ctx, prefix, size)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := &ProductSuggestions{
+		Products:   []*ProductSuggestion{},
+		Categories: suggestions.Categories,
+	}
+	for _, p := range suggestions.Products {
+		result.Products = append(result.Products, &ProductSuggestion{
+			ID:   p.ID,
+			Name: p.Name,
 		})
 	}
+
 	return result, nil
 }
 
+func (r *queryResolver) SearchSynonyms(ctx context.Context) ([]string, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	return r.server.productClient.GetSearchSynonyms(ctx)
+}
+
+func (r *queryResolver) ExchangeRates(ctx context.Context) (*ExchangeRates, error) {
+	rates, err := r.server.exchangeRates(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newExchangeRates(rates), nil
+}
+
+// CategorySchema is null for categories without attributes
+func (r *queryResolver) CategorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	schema, err := r.server.productClient.GetCategorySchema(ctx, category)
+	if errors.Is(err, product.ErrNoCategorySchema) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newCategorySchema(schema), nil
+}
+
 func (p PaginationInput) bounds() (uint64, uint64) {
 	skipValue := uint64(0)
 	takeValue := uint64(100)
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+func (r *productResolver) Questions(ctx context.Context, obj *Product, pagination *PaginationInput) ([]*Question, error) {
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	questions, err := r.server.productClient.GetProductQuestions(ctx, obj.ID, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*Question{}
+	for _, q := range questions {
+		result = append(result, newQuestion(&q))
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) AskQuestion(ctx context.Context, productID string, body string) (*Question, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	q, err := r.server.productClient.AskQuestion(ctx, productID, accountId, body)
+	if err != nil {
+		return nil, err
+	}
+	return newQuestion(q), nil
+}
+
+// AnswerQuestion lets buyers answer alongside the seller, who the product
+// service recognises by the product's account
+func (r *mutationResolver) AnswerQuestion(ctx context.Context, questionID string, body string) (*Question, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	q, err := r.server.productClient.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := r.hasPurchased(ctx, accountId, q.ProductID)
+	if err != nil {
+		log.Printf("Error checking purchase history for account %s: %v", accountId, err)
+		return nil, err
+	}
+
+	q, err = r.server.productClient.AnswerQuestion(ctx, questionID, accountId, body, verified)
+	if err != nil {
+		return nil, err
+	}
+	return newQuestion(q), nil
+}
+
+func (r *mutationResolver) ModerateQuestion(ctx context.Context, questionID string, status ReviewStatus) (*Question, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	q, err := r.server.productClient.ModerateQuestion(ctx, questionID, strings.ToLower(string(status)))
+	if err != nil {
+		return nil, err
+	}
+	return newQuestion(q), nil
+}
+
+func (r *mutationResolver) ModerateAnswer(ctx context.Context, answerID string, status ReviewStatus) (*Question, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	q, err := r.server.productClient.ModerateAnswer(ctx, answerID, strings.ToLower(string(status)))
+	if err != nil {
+		return nil, err
+	}
+	return newQuestion(q), nil
+}
+
+func newQuestion(q *product.Question) *Question {
+	question := &Question{
+		ID:        q.ID,
+		ProductID: q.ProductID,
+		AccountID: q.AccountID,
+		Body:      q.Body,
+		Status:    ReviewStatus(strings.ToUpper(q.Status)),
+		Answers:   []*Answer{},
+		CreatedAt: q.CreatedAt,
+	}
+	for _, a := range q.Answers {
+		question.Answers = append(question.Answers, &Answer{
+			ID:               a.ID,
+			AccountID:        a.AccountID,
+			Body:             a.Body,
+			FromSeller:       a.FromSeller,
+			VerifiedPurchase: a.VerifiedPurchase,
+			Status:           ReviewStatus(strings.ToUpper(a.Status)),
+			CreatedAt:        a.CreatedAt,
+		})
+	}
+	return question
+}
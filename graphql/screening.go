@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+// Screening is only shown to the seller of the product and to admins
+func (r *productResolver) Screening(ctx context.Context, obj *Product) (*ProductScreening, error) {
+	if obj.Screening == nil {
+		return nil, nil
+	}
+	if account.GetUserId(ctx) != obj.AccountID && !r.server.isAdmin(ctx) {
+		return nil, nil
+	}
+	return obj.Screening, nil
+}
+
+func newProductScreening(s *product.Screening) *ProductScreening {
+	screening := &ProductScreening{
+		Status:     ScreeningStatus(strings.ToUpper(s.Status)),
+		Reasons:    s.Reasons,
+		FlaggedAt:  s.FlaggedAt,
+		ReviewedAt: s.ReviewedAt,
+	}
+	if screening.Reasons == nil {
+		screening.Reasons = []string{}
+	}
+	if s.Note != "" {
+		screening.Note = &s.Note
+	}
+	return screening
+}
+
+func (r *queryResolver) ScreeningRules(ctx context.Context) (*ScreeningRules, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	rules, err := r.server.productClient.GetScreeningRules(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newScreeningRules(rules), nil
+}
+
+func (r *queryResolver) FlaggedProducts(ctx context.Context, pagination *PaginationInput) ([]*Product, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	skip, take := uint64(0), uint64(10)
+	if pagination != nil {
+		skip, take = pagination.bounds()
+	}
+
+	products, err := r.server.productClient.ListFlaggedProducts(ctx, skip, take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := []*Product{}
+	for i := range products {
+		result = append(result, newProduct(&products[i]))
+	}
+	return result, nil
+}
+
+func (r *mutationResolver) UpdateScreeningRules(ctx context.Context, input ScreeningRulesInput) (*ScreeningRules, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	rules := product.ScreeningRules{
+		BannedTerms:      input.BannedTerms,
+		DetectDuplicates: input.DetectDuplicates,
+	}
+	if input.MinPrice != nil {
+		rules.MinPrice = *input.MinPrice
+	}
+	if input.MaxPrice != nil {
+		rules.MaxPrice = *input.MaxPrice
+	}
+
+	updated, err := r.server.productClient.UpdateScreeningRules(ctx, rules)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newScreeningRules(updated), nil
+}
+
+func newScreeningRules(r *product.ScreeningRules) *ScreeningRules {
+	rules := &ScreeningRules{
+		BannedTerms:      r.BannedTerms,
+		DetectDuplicates: r.DetectDuplicates,
+	}
+	if !r.MinPrice.IsZero() {
+		rules.MinPrice = &r.MinPrice
+	}
+	if !r.MaxPrice.IsZero() {
+		rules.MaxPrice = &r.MaxPrice
+	}
+	if !r.UpdatedAt.IsZero() {
+		rules.UpdatedAt = &r.UpdatedAt
+	}
+	return rules
+}
+
+func (r *mutationResolver) ApproveProduct(ctx context.Context, id string) (*Product, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	p, err := r.server.productClient.ApproveProduct(ctx, id, account.GetUserId(ctx))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) RejectProduct(ctx context.Context, id string, note *string) (*Product, error) {
+	if !r.server.isAdmin(ctx) {
+		return nil, ErrForbidden
+	}
+
+	p, err := r.server.productClient.RejectProduct(ctx, id, account.GetUserId(ctx), stringValue(note))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return newProduct(p), nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/account"
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+)
+
+// Wishlists shows other accounts only the public wishlists of obj
+func (r *accountResolver) Wishlists(ctx context.Context, obj *Account) ([]*Wishlist, error) {
+	publicOnly := account.GetUserId(ctx) != obj.ID
+
+	lists, err := r.server.productClient.ListWishlists(ctx, obj.ID, publicOnly)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.server.newWishlists(ctx, lists...)
+}
+
+func (r *queryResolver) SharedWishlist(ctx context.Context, token string) (*Wishlist, error) {
+	w, err := r.server.productClient.GetSharedWishlist(ctx, token)
+	if errors.Is(err, product.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (r *mutationResolver) CreateWishlist(ctx context.Context, name string, public *bool) (*Wishlist, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	w, err := r.server.productClient.CreateWishlist(ctx, accountId, name, public != nil && *public)
+	if err != nil {
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (r *mutationResolver) UpdateWishlist(ctx context.Context, id string, name *string, public *bool) (*Wishlist, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	w, err := r.server.productClient.UpdateWishlist(ctx, id, accountId, name, public)
+	if err != nil {
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (r *mutationResolver) DeleteWishlist(ctx context.Context, id string) (*bool, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	if err := r.server.productClient.DeleteWishlist(ctx, id, accountId); err != nil {
+		return nil, err
+	}
+	deleted := true
+	return &deleted, nil
+}
+
+func (r *mutationResolver) AddToWishlist(ctx context.Context, productId string, wishlistId *string) (*Wishlist, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	w, err := r.server.productClient.AddToWishlist(ctx, accountId, stringValue(wishlistId), productId)
+	if err != nil {
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (r *mutationResolver) RemoveFromWishlist(ctx context.Context, wishlistId, productId string) (*Wishlist, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	w, err := r.server.productClient.RemoveFromWishlist(ctx, accountId, wishlistId, productId)
+	if err != nil {
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (r *mutationResolver) MoveWishlistItem(ctx context.Context, productId, fromWishlistId, toWishlistId string) (*Wishlist, error) {
+	accountId := account.GetUserId(ctx)
+	if accountId == "" {
+		return nil, errors.New("unauthorized")
+	}
+
+	w, err := r.server.productClient.MoveWishlistItem(ctx, accountId, productId, fromWishlistId, toWishlistId)
+	if err != nil {
+		return nil, err
+	}
+	return r.server.newWishlist(ctx, w)
+}
+
+func (s *Server) newWishlist(ctx context.Context, w *product.Wishlist) (*Wishlist, error) {
+	lists, err := s.newWishlists(ctx, *w)
+	if err != nil {
+		return nil, err
+	}
+	return lists[0], nil
+}
+
+// newWishlists converts wishlists, fetching the products of all their items
+// in one request
+func (s *Server) newWishlists(ctx context.Context, lists ...product.Wishlist) ([]*Wishlist, error) {
+	var ids []string
+	for _, w := range lists {
+		for _, item := range w.Items {
+			ids = append(ids, item.ProductID)
+		}
+	}
+
+	products := map[string]*Product{}
+	if len(ids) > 0 {
+		found, err := s.productClient.GetProducts(ctx, 0, 0, ids, "")
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		for _, p := range found {
+			if p.ArchivedAt == nil {
+				products[p.ID] = newProduct(&p)
+			}
+		}
+	}
+
+	result := []*Wishlist{}
+	for _, w := range lists {
+		wishlist := &Wishlist{
+			ID:        w.ID,
+			Name:      w.Name,
+			IsDefault: w.Default,
+			Items:     []*WishlistItem{},
+			CreatedAt: w.CreatedAt,
+		}
+		if w.ShareToken != "" {
+			wishlist.ShareToken = &w.ShareToken
+		}
+		for _, item := range w.Items {
+			wishlist.Items = append(wishlist.Items, &WishlistItem{
+				ProductID: item.ProductID,
+				Product:   products[item.ProductID],
+				AddedAt:   item.AddedAt,
+			})
+		}
+		result = append(result, wishlist)
+	}
+	return result, nil
+}
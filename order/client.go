@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-systems-lab/go-ecommerce-lld/order/pb"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -32,7 +33,9 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error) {
+// PostOrder places an order charged in currency, or in the products' own
+// currency when it is empty
+func (c *Client) PostOrder(ctx context.Context, accountID, currency string, products []OrderedProduct) (*Order, error) {
 	var protoProducts []*pb.OrderProduct
 	for _, product := range products {
 		protoProducts = append(protoProducts, &pb.OrderProduct{
@@ -44,6 +47,7 @@ func (c *Client) PostOrder(ctx context.Context, accountID string, products []Ord
 	r, err := c.service.PostOrder(ctx, &pb.PostOrderRequest{
 		AccountId: accountID,
 		Products:  protoProducts,
+		Currency:  currency,
 	})
 
 	if err != nil {
@@ -61,8 +65,9 @@ func (c *Client) PostOrder(ctx context.Context, accountID string, products []Ord
 			ID:          p.Id,
 			Name:        p.Name,
 			Description: p.Description,
-			Price:       p.Price,
+			Price:       fromProtoMoney(p.GetPrice()),
 			Quantity:    p.Quantity,
+			BundleItems: fromProtoBundleItems(p.GetBundleItems()),
 		})
 	}
 
@@ -70,8 +75,11 @@ func (c *Client) PostOrder(ctx context.Context, accountID string, products []Ord
 		ID:         newOrder.Id,
 		CreatedAt:  newOrderCreatedAt,
 		AccountID:  newOrder.AccountId,
-		TotalPrice: newOrder.TotalPrice,
+		TotalPrice: fromProtoMoney(newOrder.GetTotalPrice()),
 		Products:   responseProducts,
+
+		SourceCurrency: newOrder.SourceCurrency,
+		ExchangeRate:   newOrder.ExchangeRate,
 	}, nil
 }
 
@@ -88,8 +96,11 @@ func (c *Client) GetOrdersForAccount(ctx context.Context, accountID string) ([]O
 	for _, orderProto := range r.Orders {
 		newOrder := Order{
 			ID:         orderProto.Id,
-			TotalPrice: orderProto.TotalPrice,
+			TotalPrice: fromProtoMoney(orderProto.GetTotalPrice()),
 			AccountID:  orderProto.AccountId,
+
+			SourceCurrency: orderProto.SourceCurrency,
+			ExchangeRate:   orderProto.ExchangeRate,
 		}
 		newOrder.CreatedAt = time.Time{}
 		newOrder.CreatedAt.UnmarshalBinary(orderProto.CreatedAt)
@@ -101,7 +112,8 @@ func (c *Client) GetOrdersForAccount(ctx context.Context, accountID string) ([]O
 				Quantity:    p.Quantity,
 				Name:        p.Name,
 				Description: p.Description,
-				Price:       p.Price,
+				Price:       fromProtoMoney(p.GetPrice()),
+				BundleItems: fromProtoBundleItems(p.GetBundleItems()),
 			})
 		}
 		newOrder.Products = products
@@ -110,3 +122,19 @@ func (c *Client) GetOrdersForAccount(ctx context.Context, accountID string) ([]O
 	}
 	return orders, nil
 }
+
+func fromProtoBundleItems(items []*pb.BundleItem) []BundleItem {
+	var result []BundleItem
+	for _, item := range items {
+		result = append(result, BundleItem{ProductID: item.GetProductId(), Quantity: item.GetQuantity()})
+	}
+	return result
+}
+
+func toProtoMoney(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func fromProtoMoney(m *pb.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
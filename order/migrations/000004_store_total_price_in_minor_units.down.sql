@@ -0,0 +1,9 @@
+-- Revert order totals to DECIMAL(10,2). Totals in currencies without two
+-- decimal places are converted as if they had them.
+ALTER TABLE orders ADD COLUMN total_price DECIMAL(10,2);
+
+UPDATE orders SET total_price = total_price_amount / 100.0;
+
+ALTER TABLE orders ALTER COLUMN total_price SET NOT NULL;
+ALTER TABLE orders DROP COLUMN currency;
+ALTER TABLE orders DROP COLUMN total_price_amount;
@@ -0,0 +1,10 @@
+-- Store order totals as integer minor units (e.g. cents) with an ISO 4217
+-- currency code instead of a decimal. Existing totals were in USD.
+ALTER TABLE orders ADD COLUMN total_price_amount BIGINT;
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+UPDATE orders SET total_price_amount = ROUND(total_price * 100);
+
+ALTER TABLE orders ALTER COLUMN total_price_amount SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
+ALTER TABLE orders DROP COLUMN total_price;
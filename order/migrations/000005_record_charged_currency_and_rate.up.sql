@@ -0,0 +1,9 @@
+-- Orders are charged in the currency the shopper chose. Record the currency
+-- products were priced in and the rate used to convert them. Existing orders
+-- were charged in the products' own currency.
+ALTER TABLE orders ADD COLUMN source_currency CHAR(3);
+ALTER TABLE orders ADD COLUMN exchange_rate NUMERIC(20,10) NOT NULL DEFAULT 1;
+
+UPDATE orders SET source_currency = currency;
+
+ALTER TABLE orders ALTER COLUMN source_currency SET NOT NULL;
@@ -0,0 +1,6 @@
+DELETE FROM order_products WHERE bundle_id <> '';
+
+ALTER TABLE order_products DROP CONSTRAINT order_products_pkey;
+ALTER TABLE order_products ADD PRIMARY KEY (product_id, order_id);
+
+ALTER TABLE order_products DROP COLUMN bundle_id;
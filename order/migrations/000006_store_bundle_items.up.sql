@@ -0,0 +1,7 @@
+-- The components of a bundle line are stored as rows pointing at the bundle
+-- through bundle_id, so a product can be ordered on its own and as part of
+-- bundles in the same order. Lines of their own have an empty bundle_id.
+ALTER TABLE order_products ADD COLUMN bundle_id VARCHAR(36) NOT NULL DEFAULT '';
+
+ALTER TABLE order_products DROP CONSTRAINT order_products_pkey;
+ALTER TABLE order_products ADD PRIMARY KEY (order_id, product_id, bundle_id);
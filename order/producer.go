@@ -10,6 +10,7 @@ import (
 type EventData struct {
 	AccountId string `json:"user_id"`
 	ProductId string `json:"product_id"`
+	Quantity  uint32 `json:"quantity,omitempty"`
 }
 
 type Event struct {
@@ -46,29 +46,38 @@ func (r postgresRepository) PutOrder(ctx context.Context, order Order) error {
 	defer tx.Rollback(ctx)
 
 	query := `
-		INSERT INTO orders (id, created_at, account_id, total_price)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO orders (id, created_at, account_id, total_price_amount, currency, source_currency, exchange_rate)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		ON CONFLICT (id) DO UPDATE SET
 			created_at = $2,
 			account_id = $3,
-			total_price = $4
+			total_price_amount = $4,
+			currency = $5,
+			source_currency = $6,
+			exchange_rate = $7
 	`
 
-	_, err = tx.Exec(ctx, query, order.ID, order.CreatedAt, order.AccountID, order.TotalPrice)
+	_, err = tx.Exec(ctx, query, order.ID, order.CreatedAt, order.AccountID, order.TotalPrice.Amount, order.TotalPrice.Currency, order.SourceCurrency, order.ExchangeRate)
 	if err != nil {
 		return err
 	}
 
 	query = `
-		INSERT INTO order_products (order_id, product_id, quantity)
-		VALUES ($1, $2, $3)
+		INSERT INTO order_products (order_id, product_id, quantity, bundle_id)
+		VALUES ($1, $2, $3, $4)
 	`
 
 	for _, product := range order.Products {
-		_, err = tx.Exec(ctx, query, order.ID, product.ID, product.Quantity)
+		_, err = tx.Exec(ctx, query, order.ID, product.ID, product.Quantity, "")
 		if err != nil {
 			return err
 		}
+		for _, item := range product.BundleItems {
+			_, err = tx.Exec(ctx, query, order.ID, item.ProductID, item.Quantity, product.ID)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -79,12 +88,13 @@ func (r postgresRepository) PutOrder(ctx context.Context, order Order) error {
 }
 
 func (r postgresRepository) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
+	// Lines of their own sort before the bundle items that refer to them
 	query := `
-		SELECT o.id, o.created_at, o.account_id, o.total_price, op.product_id, op.quantity
+		SELECT o.id, o.created_at, o.account_id, o.total_price_amount, o.currency, o.source_currency, trim_scale(o.exchange_rate)::text, op.product_id, op.quantity, op.bundle_id
 		FROM orders o
 		JOIN order_products op ON o.id = op.order_id
 		WHERE account_id = $1
-		ORDER BY o.id
+		ORDER BY o.id, op.bundle_id
 	`
 
 	rows, err := r.db.Query(ctx, query, accountID)
@@ -98,15 +108,20 @@ func (r postgresRepository) GetOrdersForAccount(ctx context.Context, accountID s
 	var lastOrderID string
 	order := &Order{}
 	orderedProduct := &OrderedProduct{}
+	var bundleID string
 
 	for rows.Next() {
 		if err = rows.Scan(
 			&order.ID,
 			&order.CreatedAt,
 			&order.AccountID,
-			&order.TotalPrice,
+			&order.TotalPrice.Amount,
+			&order.TotalPrice.Currency,
+			&order.SourceCurrency,
+			&order.ExchangeRate,
 			&orderedProduct.ID,
 			&orderedProduct.Quantity,
+			&bundleID,
 		); err != nil {
 			return nil, err
 		}
@@ -116,13 +131,25 @@ func (r postgresRepository) GetOrdersForAccount(ctx context.Context, accountID s
 			orders = append(orders, *order)
 			products = []OrderedProduct{}
 		}
+		lastOrderID = order.ID
+
+		if bundleID != "" {
+			for i := range products {
+				if products[i].ID == bundleID {
+					products[i].BundleItems = append(products[i].BundleItems, BundleItem{
+						ProductID: orderedProduct.ID,
+						Quantity:  orderedProduct.Quantity,
+					})
+					break
+				}
+			}
+			continue
+		}
 
 		products = append(products, OrderedProduct{
 			ID:       orderedProduct.ID,
 			Quantity: orderedProduct.Quantity,
 		})
-
-		lastOrderID = order.ID
 	}
 
 	if lastOrderID != "" {
@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
@@ -10,9 +11,12 @@ import (
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/go-systems-lab/go-ecommerce-lld/account"
 	"github.com/go-systems-lab/go-ecommerce-lld/order/pb"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"github.com/go-systems-lab/go-ecommerce-lld/product"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 type grpcServer struct {
@@ -62,41 +66,30 @@ func (s *grpcServer) PostOrder(ctx context.Context, request *pb.PostOrderRequest
 		return nil, err
 	}
 
-	log.Printf("Fetching product details (optimistic approach)...")
 	var orderedProducts []product.Product
-	var asyncProductIDs []string
-
 	for _, p := range request.Products {
-		log.Printf("Fetching product with ID: %s", p.Id)
-
-		// Try immediate fetch - don't block if not found
 		fetchedProduct, err := s.productClient.GetProduct(ctx, p.Id)
-		if err == nil {
-			log.Printf("✅ Product found immediately: %s", fetchedProduct.Name)
-			orderedProducts = append(orderedProducts, *fetchedProduct)
-		} else {
-			log.Printf("⏳ Product %s not immediately available, will retry asynchronously", p.Id)
-			asyncProductIDs = append(asyncProductIDs, p.Id)
-
-			// Create placeholder product for order (optimistic)
-			orderedProducts = append(orderedProducts, product.Product{
-				ID:    p.Id,
-				Name:  "Product (Processing...)",
-				Price: 0.0, // Placeholder price
-			})
+		if errors.Is(err, product.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%v: %s", product.ErrNotFound, p.Id)
 		}
-	}
-
-	// Start async processing for products that weren't immediately available
-	if len(asyncProductIDs) > 0 {
-		log.Printf("🚀 Starting async interaction event processing for %d products", len(asyncProductIDs))
-		go s.processInteractionEventsAsync(ctx, request.AccountId, asyncProductIDs)
+		if err != nil {
+			log.Println("Error getting product", err)
+			return nil, err
+		}
+		if !fetchedProduct.IsPublished(time.Now()) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v: %s", product.ErrNotPublished, p.Id)
+		}
+		// The stock of bundles is derived from their components
+		if fetchedProduct.Stock != nil && *fetchedProduct.Stock < int64(p.Quantity) {
+			return nil, status.Errorf(codes.FailedPrecondition, "%v: %s", ErrInsufficientStock, p.Id)
+		}
+		orderedProducts = append(orderedProducts, *fetchedProduct)
 	}
 
 	log.Printf("Retrieved %d products from product service", len(orderedProducts))
 
 	var products []OrderedProduct
-	var calculatedTotalPrice float64
+	var sourceTotal money.Money
 
 	for _, p := range orderedProducts {
 		productObj := OrderedProduct{
@@ -112,25 +105,79 @@ func (s *grpcServer) PostOrder(ctx context.Context, request *pb.PostOrderRequest
 				break
 			}
 		}
+		// Bundles are charged as one line and fulfilled from their components
+		for _, c := range p.Bundle {
+			productObj.BundleItems = append(productObj.BundleItems, BundleItem{
+				ProductID: c.ProductID,
+				Quantity:  c.Quantity * productObj.Quantity,
+			})
+		}
 
 		if productObj.Quantity != 0 {
-			// Calculate total price: price * quantity
-			calculatedTotalPrice += p.Price * float64(productObj.Quantity)
+			// Products must share a currency for a single exchange rate to apply
+			sourceTotal, err = sourceTotal.Add(p.Price.Mul(int64(productObj.Quantity)))
+			if errors.Is(err, money.ErrCurrencyMismatch) {
+				return nil, status.Error(codes.InvalidArgument, "all products in an order must be priced in the same currency")
+			}
 			products = append(products, productObj)
 		}
 	}
 
-	order, err := s.service.PostOrder(ctx, request.AccountId, calculatedTotalPrice, products)
+	sourceCurrency := sourceTotal.Currency
+	if sourceCurrency == "" {
+		// Only products without a price
+		sourceCurrency = money.DefaultCurrency
+	}
+
+	currency, rate, err := s.chargeCurrency(ctx, sourceCurrency, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert unit prices before multiplying by quantity so that every line
+	// adds up from the price shown to the shopper
+	calculatedTotalPrice := money.New(0, currency)
+	for i, p := range products {
+		price, err := money.ConvertAt(p.Price, rate, currency)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		products[i].Price = price
+		calculatedTotalPrice.Amount += price.Mul(int64(p.Quantity)).Amount
+	}
+
+	// Stock is taken before the order is stored and given back if storing
+	// fails, so concurrent orders cannot sell the same units twice
+	var stockLines []product.StockLine
+	for _, p := range products {
+		stockLines = append(stockLines, product.StockLine{ProductID: p.ID, Quantity: p.Quantity})
+	}
+	err = s.productClient.ReserveStock(ctx, stockLines)
+	if errors.Is(err, product.ErrInsufficientStock) {
+		return nil, status.Error(codes.FailedPrecondition, ErrInsufficientStock.Error())
+	}
+	if err != nil {
+		log.Println("Error reserving stock", err)
+		return nil, err
+	}
+
+	order, err := s.service.PostOrder(ctx, request.AccountId, calculatedTotalPrice, sourceCurrency, rate, products)
 	if err != nil {
 		log.Println("Error posting order", err)
+		if err := s.productClient.ReleaseStock(context.WithoutCancel(ctx), stockLines); err != nil {
+			log.Println("Error releasing stock", err)
+		}
 		return nil, err
 	}
 
 	orderProto := &pb.Order{
 		Id:         order.ID,
 		AccountId:  order.AccountID,
-		TotalPrice: order.TotalPrice,
+		TotalPrice: toProtoMoney(order.TotalPrice),
 		Products:   []*pb.OrderedProduct{},
+
+		SourceCurrency: order.SourceCurrency,
+		ExchangeRate:   order.ExchangeRate,
 	}
 	orderProto.CreatedAt, _ = order.CreatedAt.MarshalBinary()
 
@@ -140,8 +187,9 @@ func (s *grpcServer) PostOrder(ctx context.Context, request *pb.PostOrderRequest
 			Id:          p.ID,
 			Name:        p.Name,
 			Description: p.Description,
-			Price:       p.Price,
+			Price:       toProtoMoney(p.Price),
 			Quantity:    p.Quantity,
+			BundleItems: toProtoBundleItems(p.BundleItems),
 		})
 	}
 	return &pb.PostOrderResponse{
@@ -178,15 +226,19 @@ func (s *grpcServer) GetOrdersForAccount(ctx context.Context, request *pb.GetOrd
 	for _, o := range accountOrders {
 		// Encode order
 		op := &pb.Order{
-			AccountId:  o.AccountID,
-			Id:         o.ID,
-			TotalPrice: o.TotalPrice,
-			Products:   []*pb.OrderedProduct{},
+			AccountId: o.AccountID,
+			Id:        o.ID,
+			Products:  []*pb.OrderedProduct{},
+
+			SourceCurrency: o.SourceCurrency,
+			ExchangeRate:   o.ExchangeRate,
 		}
 		op.CreatedAt, _ = o.CreatedAt.MarshalBinary()
 
-		// Decorate orders with products and calculate correct total price
-		var calculatedTotalPrice float64
+		// Decorate orders with products and calculate correct total price,
+		// converting current prices at the rate the order was charged at
+		var calculatedTotalPrice money.Money
+		mixedCurrencies := false
 		for _, orderedProduct := range o.Products {
 			// Populate product fields
 			for _, p := range products {
@@ -194,8 +246,18 @@ func (s *grpcServer) GetOrdersForAccount(ctx context.Context, request *pb.GetOrd
 					orderedProduct.Name = p.Name
 					orderedProduct.Description = p.Description
 					orderedProduct.Price = p.Price
+					if p.Price.Currency != o.SourceCurrency {
+						mixedCurrencies = true
+						break
+					}
+					price, err := money.ConvertAt(p.Price, o.ExchangeRate, o.TotalPrice.Currency)
+					if err != nil {
+						mixedCurrencies = true
+						break
+					}
+					orderedProduct.Price = price
 					// Calculate total price correctly: price * quantity
-					calculatedTotalPrice += p.Price * float64(orderedProduct.Quantity)
+					calculatedTotalPrice, _ = calculatedTotalPrice.Add(price.Mul(int64(orderedProduct.Quantity)))
 					break
 				}
 			}
@@ -204,66 +266,60 @@ func (s *grpcServer) GetOrdersForAccount(ctx context.Context, request *pb.GetOrd
 				Id:          orderedProduct.ID,
 				Name:        orderedProduct.Name,
 				Description: orderedProduct.Description,
-				Price:       orderedProduct.Price,
+				Price:       toProtoMoney(orderedProduct.Price),
 				Quantity:    orderedProduct.Quantity,
+				BundleItems: toProtoBundleItems(orderedProduct.BundleItems),
 			})
 		}
 
-		// Set the correctly calculated total price
-		op.TotalPrice = calculatedTotalPrice
+		// Set the correctly calculated total price, keeping the stored total
+		// if products have since been repriced in another currency
+		op.TotalPrice = toProtoMoney(calculatedTotalPrice)
+		if mixedCurrencies {
+			op.TotalPrice = toProtoMoney(o.TotalPrice)
+		}
 		orders = append(orders, op)
 	}
 	return &pb.GetOrdersForAccountResponse{Orders: orders}, nil
 }
 
-// processInteractionEventsAsync handles async interaction event processing with retries
-func (s *grpcServer) processInteractionEventsAsync(ctx context.Context, accountID string, productIDs []string) {
-	maxRetries := 3
-	baseDelay := 2 * time.Second
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		allFound := true
-		var validProducts []product.Product
-
-		for _, productID := range productIDs {
-			product, err := s.productClient.GetProduct(ctx, productID)
-			if err != nil {
-				log.Printf("🔄 Async attempt %d: Product %s not found yet", attempt+1, productID)
-				allFound = false
-				break
-			}
-			validProducts = append(validProducts, *product)
-		}
-
-		if allFound {
-			log.Printf("✅ All async products found on attempt %d, sending interaction events", attempt+1)
-			// Send interaction events for all found products
-			for _, prod := range validProducts {
-				if orderSvc, ok := s.service.(*orderService); ok {
-					err := orderSvc.SendMessageToRecommender(Event{
-						Type: "purchase",
-						EventData: EventData{
-							AccountId: accountID,
-							ProductId: prod.ID,
-						},
-					}, "interaction_events")
+func toProtoBundleItems(items []BundleItem) []*pb.BundleItem {
+	var result []*pb.BundleItem
+	for _, item := range items {
+		result = append(result, &pb.BundleItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+	return result
+}
 
-					if err != nil {
-						log.Printf("❌ Error sending async interaction event for product %s: %v", prod.ID, err)
-					} else {
-						log.Printf("✅ Successfully sent async interaction event for product %s", prod.ID)
-					}
-				}
-			}
-			return
-		}
+// chargeCurrency resolves the currency an order priced in sourceCurrency is
+// charged in and the exchange rate between them
+func (s *grpcServer) chargeCurrency(ctx context.Context, sourceCurrency, requested string) (string, string, error) {
+	if requested == "" {
+		return sourceCurrency, "1", nil
+	}
+	currency, err := money.NormalizeCurrency(requested)
+	if err != nil {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if currency == sourceCurrency {
+		return currency, "1", nil
+	}
 
-		if attempt < maxRetries-1 {
-			waitTime := baseDelay * time.Duration(attempt+1)
-			log.Printf("⏳ Retrying async interaction events in %v (attempt %d/%d)", waitTime, attempt+1, maxRetries)
-			time.Sleep(waitTime)
-		}
+	rates, err := s.productClient.GetExchangeRates(ctx)
+	if errors.Is(err, product.ErrNoExchangeRates) {
+		return "", "", status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return "", "", err
+	}
+	rate, err := rates.Rate(sourceCurrency, currency)
+	if errors.Is(err, money.ErrUnknownCurrency) {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return "", "", err
 	}
 
-	log.Printf("❌ Failed to process async interaction events after %d attempts", maxRetries)
+	log.Printf("Charging order in %s at %s per %s", currency, rate, sourceCurrency)
+	return currency, rate, nil
 }
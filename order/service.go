@@ -2,20 +2,29 @@ package order
 
 import (
 	"context"
+	"errors"
 	"log"
 	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"github.com/google/uuid"
 )
 
+var ErrInsufficientStock = errors.New("not enough stock")
+
 type Order struct {
 	ID           string
 	CreatedAt    time.Time
 	AccountID    string
-	TotalPrice   float64
+	TotalPrice   money.Money
 	Products     []OrderedProduct
 	productInfos []ProductsInfo
+
+	// SourceCurrency is the currency products were priced in and
+	// ExchangeRate the units of TotalPrice.Currency charged per unit of it
+	SourceCurrency string
+	ExchangeRate   string
 }
 
 type ProductsInfo struct {
@@ -29,12 +38,21 @@ type OrderedProduct struct {
 	ID          string
 	Name        string
 	Description string
-	Price       float64
+	Price       money.Money
 	Quantity    uint32
+
+	// BundleItems are the components shipped for a bundle, Quantity units of
+	// each for the whole line. The customer only sees the bundle line.
+	BundleItems []BundleItem
+}
+
+type BundleItem struct {
+	ProductID string
+	Quantity  uint32
 }
 
 type Service interface {
-	PostOrder(ctx context.Context, accountID string, totalPrice float64, products []OrderedProduct) (*Order, error)
+	PostOrder(ctx context.Context, accountID string, totalPrice money.Money, sourceCurrency, exchangeRate string, products []OrderedProduct) (*Order, error)
 	GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error)
 }
 
@@ -47,7 +65,7 @@ func NewOrderService(repository Repository, producer sarama.AsyncProducer) Servi
 	return &orderService{repository: repository, producer: producer}
 }
 
-func (o orderService) PostOrder(ctx context.Context, accountID string, totalPrice float64, products []OrderedProduct) (*Order, error) {
+func (o orderService) PostOrder(ctx context.Context, accountID string, totalPrice money.Money, sourceCurrency, exchangeRate string, products []OrderedProduct) (*Order, error) {
 	log.Printf("PostOrder called with accountID: %s, %d products", accountID, len(products))
 
 	order := Order{
@@ -56,6 +74,9 @@ func (o orderService) PostOrder(ctx context.Context, accountID string, totalPric
 		AccountID:  accountID,
 		TotalPrice: totalPrice,
 		Products:   products,
+
+		SourceCurrency: sourceCurrency,
+		ExchangeRate:   exchangeRate,
 	}
 
 	log.Printf("Created order with ID: %s", order.ID)
@@ -78,6 +99,7 @@ func (o orderService) PostOrder(ctx context.Context, accountID string, totalPric
 				EventData: EventData{
 					AccountId: accountID,
 					ProductId: product.ID,
+					Quantity:  product.Quantity,
 				},
 			}
 
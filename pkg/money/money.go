@@ -0,0 +1,189 @@
+// Package money represents prices as an integer amount of a currency's minor
+// unit, e.g. cents, together with its ISO 4217 code, so that prices can be
+// stored and summed without floating point rounding.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for prices stored before amounts carried a
+// currency
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidCurrency  = errors.New("currency must be a three letter ISO 4217 code")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrNegativeAmount   = errors.New("amount must not be negative")
+	ErrCurrencyMismatch = errors.New("amounts are in different currencies")
+)
+
+// minorUnits lists currencies whose minor unit is not a hundredth
+var minorUnits = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// Money is an amount in the minor unit of Currency. The zero value has no
+// currency and acts as zero in any currency when added.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromFloat converts a decimal amount of currency, such as a legacy float
+// price, rounding to the nearest minor unit
+func FromFloat(value float64, currency string) Money {
+	return Money{
+		Amount:   int64(math.Round(value * Scale(currency))),
+		Currency: currency,
+	}
+}
+
+// Parse reads a decimal amount of currency such as "12.5" exactly, rejecting
+// more decimal places than the currency has
+func Parse(value, currency string) (Money, error) {
+	value = strings.TrimSpace(value)
+	sign := ""
+	if strings.HasPrefix(value, "-") {
+		sign = "-"
+		value = value[1:]
+	}
+
+	digits := MinorUnits(currency)
+	whole, frac, _ := strings.Cut(value, ".")
+	if whole == "" && frac == "" || len(frac) > digits || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	amount, err := strconv.ParseInt(sign+whole+frac+strings.Repeat("0", digits-len(frac)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, value)
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnits is the number of decimal places in currency's minor unit
+func MinorUnits(currency string) int {
+	if digits, ok := minorUnits[currency]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Scale is the number of minor units in one major unit of currency
+func Scale(currency string) float64 {
+	return math.Pow10(MinorUnits(currency))
+}
+
+// NormalizeCurrency upper-cases code and checks it looks like an ISO 4217
+// code
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	return code, nil
+}
+
+// Validate checks that m is a non-negative amount of a valid currency and
+// returns it with the currency code normalized
+func (m Money) Validate() (Money, error) {
+	currency, err := NormalizeCurrency(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if m.Amount < 0 {
+		return Money{}, ErrNegativeAmount
+	}
+	return Money{Amount: m.Amount, Currency: currency}, nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add returns m + o. Both must be in the same currency unless one of them is
+// the zero value.
+func (m Money) Add(o Money) (Money, error) {
+	switch {
+	case m == Money{}:
+		return o, nil
+	case o == Money{}:
+		return m, nil
+	case m.Currency != o.Currency:
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// Mul returns m multiplied by quantity
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}
+}
+
+// Less reports whether m is smaller than o. Amounts in different currencies
+// are never less than each other.
+func (m Money) Less(o Money) bool {
+	return m.Currency == o.Currency && m.Amount < o.Amount
+}
+
+// Float returns the amount in major units. It is only meant for display and
+// for systems that cannot store minor units.
+func (m Money) Float() float64 {
+	return float64(m.Amount) / Scale(m.Currency)
+}
+
+// String formats m as a decimal amount followed by its currency, e.g.
+// "12.50 USD"
+func (m Money) String() string {
+	return strings.TrimSpace(m.Decimal() + " " + m.Currency)
+}
+
+// Decimal formats the amount in major units with every minor unit digit,
+// e.g. "12.50"
+func (m Money) Decimal() string {
+	digits := MinorUnits(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	s := strconv.FormatInt(amount, 10)
+	if digits > 0 {
+		if len(s) <= digits {
+			s = strings.Repeat("0", digits-len(s)+1) + s
+		}
+		s = s[:len(s)-digits] + "." + s[len(s)-digits:]
+	}
+	return sign + s
+}
@@ -0,0 +1,178 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// RateDecimals is the precision exchange rates are kept and applied at. Every
+// conversion uses the rate rounded to this many decimal places, which is also
+// the rate recorded against orders.
+const RateDecimals = 10
+
+var (
+	ErrInvalidRate     = errors.New("exchange rate must be a positive decimal")
+	ErrUnknownCurrency = errors.New("no exchange rate for currency")
+)
+
+// ExchangeRates gives the value of one unit of Base in other currencies, as
+// decimal strings
+type ExchangeRates struct {
+	Base      string            `json:"base"`
+	Rates     map[string]string `json:"rates"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// ReadExchangeRates reads rates from JSON such as
+// {"base": "USD", "rates": {"EUR": "0.92", "JPY": 151.3}}
+func ReadExchangeRates(r io.Reader) (ExchangeRates, error) {
+	var file struct {
+		Base  string                 `json:"base"`
+		Rates map[string]json.Number `json:"rates"`
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&file); err != nil {
+		return ExchangeRates{}, err
+	}
+
+	rates := ExchangeRates{Base: file.Base, Rates: make(map[string]string, len(file.Rates))}
+	for currency, rate := range file.Rates {
+		rates.Rates[currency] = rate.String()
+	}
+	return rates.Validate()
+}
+
+// Validate normalizes currency codes and rates. The base currency always has
+// a rate of 1.
+func (r ExchangeRates) Validate() (ExchangeRates, error) {
+	base, err := NormalizeCurrency(r.Base)
+	if err != nil {
+		return ExchangeRates{}, err
+	}
+
+	rates := make(map[string]string, len(r.Rates)+1)
+	for code, value := range r.Rates {
+		currency, err := NormalizeCurrency(code)
+		if err != nil {
+			return ExchangeRates{}, fmt.Errorf("%w: %q", err, code)
+		}
+		rate, err := parseRate(value)
+		if err != nil {
+			return ExchangeRates{}, fmt.Errorf("%w: %s %q", err, currency, value)
+		}
+		if rates[currency] = formatRate(rate); rates[currency] == "0" {
+			return ExchangeRates{}, fmt.Errorf("%w: %s %q is below the supported precision", ErrInvalidRate, currency, value)
+		}
+	}
+	if rate, ok := rates[base]; ok && rate != "1" {
+		return ExchangeRates{}, fmt.Errorf("%w: base currency %s must have a rate of 1", ErrInvalidRate, base)
+	}
+	rates[base] = "1"
+
+	return ExchangeRates{Base: base, Rates: rates, UpdatedAt: r.UpdatedAt}, nil
+}
+
+// Rate returns how many units of to one unit of from is worth, rounded to
+// RateDecimals
+func (r ExchangeRates) Rate(from, to string) (string, error) {
+	if from == to {
+		return "1", nil
+	}
+
+	fromRate, err := r.baseRate(from)
+	if err != nil {
+		return "", err
+	}
+	toRate, err := r.baseRate(to)
+	if err != nil {
+		return "", err
+	}
+	return formatRate(new(big.Rat).Quo(toRate, fromRate)), nil
+}
+
+func (r ExchangeRates) baseRate(currency string) (*big.Rat, error) {
+	if currency == r.Base {
+		return big.NewRat(1, 1), nil
+	}
+	value, ok := r.Rates[currency]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+	return parseRate(value)
+}
+
+// Convert returns m in currency to together with the rate that was applied
+func (r ExchangeRates) Convert(m Money, to string) (Money, string, error) {
+	rate, err := r.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, "", err
+	}
+	converted, err := ConvertAt(m, rate, to)
+	return converted, rate, err
+}
+
+// ConvertAt converts m to currency to at rate units of to per unit of
+// m.Currency. The result is rounded half away from zero to the minor unit of
+// to.
+func ConvertAt(m Money, rate, to string) (Money, error) {
+	r, err := parseRate(rate)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// Minor units of to = minor units of m * rate * 10^(digits(to) - digits(from))
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), r)
+	shift := MinorUnits(to) - MinorUnits(m.Currency)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift >= 0 {
+		amount.Mul(amount, scale)
+	} else {
+		amount.Quo(amount, scale)
+	}
+
+	rounded := roundHalfAwayFromZero(amount)
+	if !rounded.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s in %s overflows", ErrInvalidAmount, m, to)
+	}
+	return Money{Amount: rounded.Int64(), Currency: to}, nil
+}
+
+func parseRate(value string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok || rate.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return rate, nil
+}
+
+// formatRate rounds rate to RateDecimals and drops trailing zeros
+func formatRate(rate *big.Rat) string {
+	s := rate.FloatString(RateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func roundHalfAwayFromZero(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	// (2|num| + den) / 2den
+	num.Mul(num, big.NewInt(2))
+	num.Add(num, r.Denom())
+	quo := num.Quo(num, new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if r.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
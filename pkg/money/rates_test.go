@@ -0,0 +1,61 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConvertAt(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		rate string
+		to   string
+		want Money
+	}{
+		{name: "same minor units", m: Money{Amount: 1000, Currency: "USD"}, rate: "0.9", to: "EUR", want: Money{Amount: 900, Currency: "EUR"}},
+		{name: "half rounds up", m: Money{Amount: 3, Currency: "USD"}, rate: "0.5", to: "EUR", want: Money{Amount: 2, Currency: "EUR"}},
+		{name: "half of a negative amount rounds down", m: Money{Amount: -3, Currency: "USD"}, rate: "0.5", to: "EUR", want: Money{Amount: -2, Currency: "EUR"}},
+		{name: "below half rounds down", m: Money{Amount: 1, Currency: "USD"}, rate: "0.49", to: "EUR", want: Money{Amount: 0, Currency: "EUR"}},
+		{name: "to fewer minor units", m: Money{Amount: 1050, Currency: "USD"}, rate: "150", to: "JPY", want: Money{Amount: 1575, Currency: "JPY"}},
+		{name: "half to fewer minor units", m: Money{Amount: 1, Currency: "USD"}, rate: "150", to: "JPY", want: Money{Amount: 2, Currency: "JPY"}},
+		{name: "to more minor units", m: Money{Amount: 1000, Currency: "USD"}, rate: "0.307", to: "KWD", want: Money{Amount: 3070, Currency: "KWD"}},
+		{name: "half from fewer minor units", m: Money{Amount: 1, Currency: "JPY"}, rate: "0.005", to: "USD", want: Money{Amount: 1, Currency: "USD"}},
+		{name: "rate with spaces", m: Money{Amount: 1000, Currency: "USD"}, rate: " 1.25 ", to: "CAD", want: Money{Amount: 1250, Currency: "CAD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertAt(tt.m, tt.rate, tt.to)
+			if err != nil {
+				t.Fatalf("ConvertAt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ConvertAt(%v, %s, %s) = %v, want %v", tt.m, tt.rate, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertAtInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Money
+		rate string
+		want error
+	}{
+		{name: "zero rate", m: Money{Amount: 1000, Currency: "USD"}, rate: "0", want: ErrInvalidRate},
+		{name: "negative rate", m: Money{Amount: 1000, Currency: "USD"}, rate: "-1.2", want: ErrInvalidRate},
+		{name: "not a number", m: Money{Amount: 1000, Currency: "USD"}, rate: "abc", want: ErrInvalidRate},
+		{name: "overflow", m: Money{Amount: math.MaxInt64, Currency: "USD"}, rate: "2", want: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConvertAt(tt.m, tt.rate, "EUR"); !errors.Is(err, tt.want) {
+				t.Errorf("ConvertAt() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
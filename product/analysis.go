@@ -0,0 +1,203 @@
+package product
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const synonymSetID = "catalog-synonyms"
+
+var (
+	ErrInvalidSynonymRule = errors.New("synonym rules must be comma separated terms or use =>")
+)
+
+// catalogAnalysis stems English text at index time and additionally expands
+// synonyms at search time. Synonyms come from an updateable synonyms set so
+// admins can change them without reindexing.
+var catalogAnalysis = map[string]interface{}{
+	"filter": map[string]interface{}{
+		"catalog_possessive": map[string]interface{}{
+			"type":     "stemmer",
+			"language": "possessive_english",
+		},
+		"catalog_stemmer": map[string]interface{}{
+			"type":     "stemmer",
+			"language": "light_english",
+		},
+		"catalog_synonyms": map[string]interface{}{
+			"type":         "synonym_graph",
+			"synonyms_set": synonymSetID,
+			"updateable":   true,
+		},
+	},
+	"analyzer": map[string]interface{}{
+		"catalog_text": map[string]interface{}{
+			"tokenizer": "standard",
+			"filter":    []string{"lowercase", "asciifolding", "catalog_possessive", "catalog_stemmer"},
+		},
+		"catalog_search": map[string]interface{}{
+			"tokenizer": "standard",
+			"filter":    []string{"lowercase", "asciifolding", "catalog_synonyms", "catalog_possessive", "catalog_stemmer"},
+		},
+	},
+}
+
+var stemmedField = map[string]interface{}{
+	"type":            "text",
+	"analyzer":        "catalog_text",
+	"search_analyzer": "catalog_search",
+}
+
+func (p productService) GetSearchSynonyms(ctx context.Context) ([]string, error) {
+	return p.repo.GetSynonyms(ctx)
+}
+
+func (p productService) UpdateSearchSynonyms(ctx context.Context, rules []string) ([]string, error) {
+	var cleaned []string
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+		if !strings.Contains(rule, ",") && !strings.Contains(rule, "=>") {
+			return nil, ErrInvalidSynonymRule
+		}
+		cleaned = append(cleaned, rule)
+	}
+
+	if err := p.repo.PutSynonyms(ctx, cleaned); err != nil {
+		return nil, err
+	}
+
+	return cleaned, nil
+}
+
+// LoadSynonymsFile reads synonym rules in Solr format, one rule per line
+func LoadSynonymsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rules []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, line)
+	}
+
+	return rules, scanner.Err()
+}
+
+func (r *elasticRepository) ensureSynonymSet(ctx context.Context) error {
+	res, err := r.client.SynonymsGetSynonym(
+		synonymSetID,
+		r.client.SynonymsGetSynonym.WithSize(1),
+		r.client.SynonymsGetSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return r.PutSynonyms(ctx, nil)
+	}
+	if res.IsError() {
+		return fmt.Errorf("failed to get synonyms set: %s", res.String())
+	}
+	return nil
+}
+
+func (r *elasticRepository) GetSynonyms(ctx context.Context) ([]string, error) {
+	res, err := r.client.SynonymsGetSynonym(
+		synonymSetID,
+		r.client.SynonymsGetSynonym.WithSize(10000),
+		r.client.SynonymsGetSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get synonyms set: %s", res.String())
+	}
+
+	var result struct {
+		SynonymsSet []struct {
+			Synonyms string `json:"synonyms"`
+		} `json:"synonyms_set"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	rules := []string{}
+	for _, rule := range result.SynonymsSet {
+		rules = append(rules, rule.Synonyms)
+	}
+	return rules, nil
+}
+
+func (r *elasticRepository) PutSynonyms(ctx context.Context, rules []string) error {
+	set := []map[string]string{}
+	for _, rule := range rules {
+		set = append(set, map[string]string{"synonyms": rule})
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"synonyms_set": set})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.SynonymsPutSynonym(
+		synonymSetID,
+		bytes.NewReader(body),
+		r.client.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to update synonyms set: %s", res.String())
+	}
+	return nil
+}
+
+// GetSynonyms returns the rules stored in Postgres, which the catalog's
+// synonyms set is kept in step with. Until rules are stored the set's rules
+// are returned, so rules set before Postgres kept them are not replaced by
+// the defaults on startup.
+func (r *postgresRepository) GetSynonyms(ctx context.Context) ([]string, error) {
+	rules := []string{}
+	err := r.getSetting(ctx, synonymsSetting, &rules)
+	if errors.Is(err, ErrNotFound) {
+		return r.elasticRepository.GetSynonyms(ctx)
+	}
+	return rules, err
+}
+
+// PutSynonyms stores rules before updating the catalog's synonyms set, so a
+// failed update can be retried, or is applied by the next catalog rebuild
+func (r *postgresRepository) PutSynonyms(ctx context.Context, rules []string) error {
+	if rules == nil {
+		rules = []string{}
+	}
+	if err := r.putSetting(ctx, synonymsSetting, rules); err != nil {
+		return err
+	}
+	return r.elasticRepository.PutSynonyms(ctx, rules)
+}
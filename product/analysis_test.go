@@ -0,0 +1,325 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+)
+
+// synonymRepository records the rules passed to PutSynonyms. Every other
+// Repository method panics.
+type synonymRepository struct {
+	Repository
+	rules []string
+	put   bool
+}
+
+func (r *synonymRepository) PutSynonyms(ctx context.Context, rules []string) error {
+	r.rules = rules
+	r.put = true
+	return nil
+}
+
+func TestUpdateSearchSynonyms(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []string
+		want  []string
+		err   error
+	}{
+		{
+			name:  "equivalent and explicit rules",
+			rules: []string{"tee shirt, t-shirt, tshirt", "ipod, i-pod => ipod"},
+			want:  []string{"tee shirt, t-shirt, tshirt", "ipod, i-pod => ipod"},
+		},
+		{
+			name:  "trims rules and skips blanks and comments",
+			rules: []string{"  couch, sofa  ", "", "   ", "# furniture", "tv => television"},
+			want:  []string{"couch, sofa", "tv => television"},
+		},
+		{
+			name:  "rule without separator",
+			rules: []string{"couch, sofa", "hoodie"},
+			err:   ErrInvalidSynonymRule,
+		},
+		{
+			name:  "commented out rule without separator",
+			rules: []string{"# hoodie"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &synonymRepository{}
+			service := productService{repo: repo}
+
+			got, err := service.UpdateSearchSynonyms(context.Background(), tt.rules)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("UpdateSearchSynonyms() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if repo.put {
+					t.Fatalf("PutSynonyms called with %q after an invalid rule", repo.rules)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UpdateSearchSynonyms() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(repo.rules, tt.want) {
+				t.Errorf("PutSynonyms() rules = %q, want %q", repo.rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSynonymsFile(t *testing.T) {
+	rules, err := LoadSynonymsFile("synonyms.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) == 0 {
+		t.Fatal("LoadSynonymsFile() returned no rules")
+	}
+
+	service := productService{repo: &synonymRepository{}}
+	if _, err := service.UpdateSearchSynonyms(context.Background(), rules); err != nil {
+		t.Errorf("synonyms.txt does not validate: %v", err)
+	}
+}
+
+func TestCatalogAnalyzers(t *testing.T) {
+	analyzers := catalogAnalysis["analyzer"].(map[string]interface{})
+
+	text := analyzers["catalog_text"].(map[string]interface{})["filter"].([]string)
+	if slices.Contains(text, "catalog_synonyms") {
+		t.Errorf("catalog_text expands synonyms at index time: %q", text)
+	}
+
+	search := analyzers["catalog_search"].(map[string]interface{})["filter"].([]string)
+	synonyms := slices.Index(search, "catalog_synonyms")
+	if synonyms == -1 {
+		t.Fatalf("catalog_search does not expand synonyms: %q", search)
+	}
+	// Synonyms are matched before stemming, so rules are written in plain words
+	if stemmer := slices.Index(search, "catalog_stemmer"); stemmer < synonyms {
+		t.Errorf("catalog_search stems before expanding synonyms: %q", search)
+	}
+}
+
+// TestCatalogRelevance searches a fixture catalog indexed with the catalog
+// analyzers and mapping. It needs an Elasticsearch cluster and is skipped
+// unless ELASTICSEARCH_TEST_URL is set. The test creates its own index and
+// synonyms set, so the cluster's catalog is left untouched.
+func TestCatalogRelevance(t *testing.T) {
+	url := os.Getenv("ELASTICSEARCH_TEST_URL")
+	if url == "" {
+		t.Skip("ELASTICSEARCH_TEST_URL is not set")
+	}
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{url},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffix := time.Now().UnixNano()
+	index := fmt.Sprintf("catalog-test-%d", suffix)
+	synonymSet := fmt.Sprintf("catalog-synonyms-test-%d", suffix)
+
+	rules, err := LoadSynonymsFile("synonyms.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	putTestSynonyms(t, client, synonymSet, rules)
+	t.Cleanup(func() {
+		res, err := client.SynonymsDeleteSynonym(synonymSet)
+		if err == nil {
+			res.Body.Close()
+		}
+	})
+
+	createTestCatalog(t, client, index, synonymSet)
+	t.Cleanup(func() {
+		res, err := client.Indices.Delete([]string{index})
+		if err == nil {
+			res.Body.Close()
+		}
+	})
+
+	indexFixtures(t, client, index, "testdata/catalog.json")
+
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		exclude []string
+	}{
+		{name: "typo", query: "iphon", want: "iphone-15", exclude: []string{"cotton-tshirt", "sofa-3-seat"}},
+		{name: "synonym", query: "tee shirt", want: "cotton-tshirt", exclude: []string{"iphone-15", "zip-hoodie"}},
+		{name: "multi word synonym", query: "hooded sweatshirt", want: "zip-hoodie", exclude: []string{"cotton-tshirt"}},
+		{name: "single word synonym", query: "couch", want: "sofa-3-seat"},
+		{name: "stemmed", query: "smartphones", want: "iphone-15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := searchTestCatalog(t, client, index, tt.query)
+			if !slices.Contains(ids, tt.want) {
+				t.Errorf("search %q = %q, want %s", tt.query, ids, tt.want)
+			}
+			for _, id := range tt.exclude {
+				if slices.Contains(ids, id) {
+					t.Errorf("search %q = %q, did not want %s", tt.query, ids, id)
+				}
+			}
+		})
+	}
+}
+
+func putTestSynonyms(t *testing.T, client *elasticsearch.Client, id string, rules []string) {
+	t.Helper()
+
+	set := []map[string]string{}
+	for _, rule := range rules {
+		set = append(set, map[string]string{"synonyms": rule})
+	}
+	body, err := json.Marshal(map[string]interface{}{"synonyms_set": set})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.SynonymsPutSynonym(id, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("failed to create synonyms set: %s", res.String())
+	}
+}
+
+// createTestCatalog creates index with the catalog mapping and analyzers,
+// reading synonyms from synonymSet instead of the catalog's set
+func createTestCatalog(t *testing.T, client *elasticsearch.Client, index, synonymSet string) {
+	t.Helper()
+
+	analysisBytes, err := json.Marshal(catalogAnalysis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var analysis map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(analysisBytes, &analysis); err != nil {
+		t.Fatal(err)
+	}
+	analysis["filter"]["catalog_synonyms"]["synonyms_set"] = synonymSet
+
+	body, err := json.Marshal(map[string]interface{}{
+		"settings": map[string]interface{}{"analysis": analysis},
+		"mappings": catalogMapping,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Indices.Create(index, client.Indices.Create.WithBody(bytes.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("failed to create index %s: %s", index, res.String())
+	}
+}
+
+func indexFixtures(t *testing.T, client *elasticsearch.Client, index, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var products []Product
+	if err := json.Unmarshal(data, &products); err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, p := range products {
+		if err := enc.Encode(map[string]interface{}{
+			"index": map[string]interface{}{"_index": index, "_id": p.ID},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(newProductDocument(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := client.Bulk(&body, client.Bulk.WithRefresh("true"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		Errors bool              `json:"errors"`
+		Items  []json.RawMessage `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError() || result.Errors {
+		t.Fatalf("failed to index fixtures: %s", result.Items)
+	}
+}
+
+// searchTestCatalog runs the product search query for query against index
+// and returns the IDs of the matching products
+func searchTestCatalog(t *testing.T, client *elasticsearch.Client, index, query string) []string {
+	t.Helper()
+
+	body, err := json.Marshal(productSearch(query, 0, SortRelevance, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Search(
+		client.Search.WithIndex(index),
+		client.Search.WithBody(bytes.NewReader(body)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		t.Fatalf("search failed: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []string{}
+	for _, hit := range result.Hits.Hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
@@ -0,0 +1,90 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const DefaultArchiveRetention = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRetention = errors.New("retention must be positive")
+)
+
+// RestoreProduct brings an archived product back into search and listings
+func (p productService) RestoreProduct(ctx context.Context, productId, accountId string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	if product.ArchivedAt == nil {
+		return product, nil
+	}
+
+	product.ArchivedAt = nil
+	product.Version, err = p.repo.UpdateProduct(ctx, *product, newProductEvent("product_updated", *product))
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// PurgeArchivedProducts permanently deletes products, and their images, that
+// have been archived for longer than retention
+func (p productService) PurgeArchivedProducts(ctx context.Context, retention time.Duration) (uint64, error) {
+	if retention <= 0 {
+		return 0, ErrInvalidRetention
+	}
+
+	var purged uint64
+	before := time.Now().UTC().Add(-retention)
+	err := p.repo.ScanArchivedProducts(ctx, before, func(product Product) error {
+		if err := p.repo.DeleteProduct(ctx, product.ID); err != nil {
+			return err
+		}
+		purged++
+
+		if len(product.Images) > 0 {
+			if err := p.store.DeletePrefix(ctx, "products/"+product.ID); err != nil {
+				log.Printf("Error deleting images for product %s: %v", product.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return purged, err
+	}
+
+	log.Printf("PurgeArchivedProducts: purged %d products archived before %s", purged, before.Format(time.RFC3339))
+	return purged, nil
+}
+
+// excludeArchived restricts query to products that have not been archived
+func excludeArchived(query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must": query,
+			"must_not": map[string]interface{}{
+				"exists": map[string]interface{}{"field": "archivedAt"},
+			},
+		},
+	}
+}
+
+func (r *elasticRepository) ScanArchivedProducts(ctx context.Context, before time.Time, fn func(Product) error) error {
+	return r.scanProducts(ctx, map[string]interface{}{
+		"range": map[string]interface{}{
+			"archivedAt": map[string]interface{}{
+				"lt": before.Format(time.RFC3339),
+			},
+		},
+	}, fn)
+}
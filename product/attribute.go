@@ -0,0 +1,417 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const categorySchemasIndex = "category_schemas"
+
+// Attribute types of a category schema
+const (
+	AttributeText    = "text"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+	AttributeEnum    = "enum"
+)
+
+const maxAttributeValueLength = 256
+
+var (
+	ErrNoCategorySchema = errors.New("category has no attribute schema")
+	ErrInvalidSchema    = errors.New("invalid category schema")
+	ErrInvalidAttribute = errors.New("invalid product attribute")
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+var categorySchemasMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"category": map[string]interface{}{"type": "keyword"},
+		"attributes": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"updatedAt": map[string]interface{}{"type": "date"},
+	},
+}
+
+// attributesMapping indexes product attributes as nested name/value pairs so
+// a filter matches the name and value of the same attribute. Number
+// attributes are also indexed as numbers for range filters.
+var attributesMapping = map[string]interface{}{
+	"type": "nested",
+	"properties": map[string]interface{}{
+		"name":   map[string]interface{}{"type": "keyword"},
+		"value":  map[string]interface{}{"type": "keyword"},
+		"number": map[string]interface{}{"type": "double"},
+		"unit": map[string]interface{}{
+			"type":  "keyword",
+			"index": false,
+		},
+	},
+}
+
+// AttributeDefinition describes an attribute products of a category carry.
+// Unit only applies to numbers and AllowedValues only to enums.
+type AttributeDefinition struct {
+	Name          string   `json:"name"`
+	Label         string   `json:"label"`
+	Type          string   `json:"type"`
+	Unit          string   `json:"unit,omitempty"`
+	AllowedValues []string `json:"allowedValues,omitempty"`
+	Required      bool     `json:"required"`
+}
+
+// CategorySchema lists the attributes of products in a category
+type CategorySchema struct {
+	Category   string                `json:"category"`
+	Attributes []AttributeDefinition `json:"attributes"`
+	UpdatedAt  time.Time             `json:"updatedAt"`
+}
+
+// ProductAttribute is an attribute value of a product. Values are kept in
+// the canonical form of the attribute type, and the unit is copied from the
+// schema when the product is written.
+type ProductAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+// AttributeFilter keeps products whose attribute is one of Values, or for
+// number attributes lies between Min and Max inclusive
+type AttributeFilter struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values,omitempty"`
+	Min    *float64 `json:"min,omitempty"`
+	Max    *float64 `json:"max,omitempty"`
+}
+
+type attributeDocument struct {
+	Name   string   `json:"name"`
+	Value  string   `json:"value"`
+	Number *float64 `json:"number,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+}
+
+func newAttributeDocuments(attributes []ProductAttribute) []attributeDocument {
+	var docs []attributeDocument
+	for _, a := range attributes {
+		doc := attributeDocument{Name: a.Name, Value: a.Value, Unit: a.Unit}
+		if number, err := strconv.ParseFloat(a.Value, 64); err == nil && !math.IsInf(number, 0) && !math.IsNaN(number) {
+			doc.Number = &number
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func toProductAttributes(docs []attributeDocument) []ProductAttribute {
+	var attributes []ProductAttribute
+	for _, doc := range docs {
+		attributes = append(attributes, ProductAttribute{Name: doc.Name, Value: doc.Value, Unit: doc.Unit})
+	}
+	return attributes
+}
+
+// Validate checks a schema and returns it with labels defaulted to names
+func (s CategorySchema) Validate() (CategorySchema, error) {
+	s.Category = strings.TrimSpace(s.Category)
+	if s.Category == "" {
+		return s, fmt.Errorf("%w: category is required", ErrInvalidSchema)
+	}
+
+	seen := map[string]bool{}
+	attributes := make([]AttributeDefinition, 0, len(s.Attributes))
+	for _, a := range s.Attributes {
+		if !attributeNamePattern.MatchString(a.Name) {
+			return s, fmt.Errorf("%w: attribute name %q must be lowercase letters, digits and underscores", ErrInvalidSchema, a.Name)
+		}
+		if seen[a.Name] {
+			return s, fmt.Errorf("%w: attribute %s is defined twice", ErrInvalidSchema, a.Name)
+		}
+		seen[a.Name] = true
+
+		a.Label = strings.TrimSpace(a.Label)
+		if a.Label == "" {
+			a.Label = a.Name
+		}
+		a.Unit = strings.TrimSpace(a.Unit)
+
+		switch a.Type {
+		case AttributeText, AttributeNumber, AttributeBoolean:
+		case AttributeEnum:
+			if len(a.AllowedValues) == 0 {
+				return s, fmt.Errorf("%w: enum attribute %s needs allowed values", ErrInvalidSchema, a.Name)
+			}
+			for i, value := range a.AllowedValues {
+				if value == "" || len(value) > maxAttributeValueLength || slices.Contains(a.AllowedValues[:i], value) {
+					return s, fmt.Errorf("%w: invalid allowed value %q for %s", ErrInvalidSchema, value, a.Name)
+				}
+			}
+		default:
+			return s, fmt.Errorf("%w: attribute %s has unknown type %q", ErrInvalidSchema, a.Name, a.Type)
+		}
+		if a.Type != AttributeNumber && a.Unit != "" {
+			return s, fmt.Errorf("%w: only number attributes have units", ErrInvalidSchema)
+		}
+		if a.Type != AttributeEnum && len(a.AllowedValues) > 0 {
+			return s, fmt.Errorf("%w: only enum attributes have allowed values", ErrInvalidSchema)
+		}
+		attributes = append(attributes, a)
+	}
+	s.Attributes = attributes
+	return s, nil
+}
+
+// validateAttributes checks values against the schema of a category and
+// returns them normalized, in schema order. A category without a schema
+// takes no attributes.
+func (s *CategorySchema) validateAttributes(attributes []ProductAttribute) ([]ProductAttribute, error) {
+	if s == nil {
+		if len(attributes) > 0 {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAttribute, ErrNoCategorySchema)
+		}
+		return nil, nil
+	}
+
+	values := map[string]string{}
+	for _, a := range attributes {
+		if _, ok := values[a.Name]; ok {
+			return nil, fmt.Errorf("%w: %s is set twice", ErrInvalidAttribute, a.Name)
+		}
+		values[a.Name] = strings.TrimSpace(a.Value)
+	}
+
+	var result []ProductAttribute
+	for _, def := range s.Attributes {
+		value, ok := values[def.Name]
+		delete(values, def.Name)
+		if !ok || value == "" {
+			if def.Required {
+				return nil, fmt.Errorf("%w: %s is required", ErrInvalidAttribute, def.Name)
+			}
+			continue
+		}
+
+		value, err := def.normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ProductAttribute{Name: def.Name, Value: value, Unit: def.Unit})
+	}
+	for name := range values {
+		return nil, fmt.Errorf("%w: %s is not defined for %s", ErrInvalidAttribute, name, s.Category)
+	}
+	return result, nil
+}
+
+func (def AttributeDefinition) normalize(value string) (string, error) {
+	switch def.Type {
+	case AttributeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+			return "", fmt.Errorf("%w: %s must be a number", ErrInvalidAttribute, def.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case AttributeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be true or false", ErrInvalidAttribute, def.Name)
+		}
+		return strconv.FormatBool(b), nil
+	case AttributeEnum:
+		if !slices.Contains(def.AllowedValues, value) {
+			return "", fmt.Errorf("%w: %s must be one of %s", ErrInvalidAttribute, def.Name, strings.Join(def.AllowedValues, ", "))
+		}
+		return value, nil
+	default:
+		if len(value) > maxAttributeValueLength {
+			return "", fmt.Errorf("%w: %s is longer than %d characters", ErrInvalidAttribute, def.Name, maxAttributeValueLength)
+		}
+		return value, nil
+	}
+}
+
+func validateAttributeFilters(filters []AttributeFilter) error {
+	for _, f := range filters {
+		if f.Name == "" {
+			return fmt.Errorf("%w: filter needs an attribute name", ErrInvalidAttribute)
+		}
+		if len(f.Values) == 0 && f.Min == nil && f.Max == nil {
+			return fmt.Errorf("%w: filter on %s needs values or a range", ErrInvalidAttribute, f.Name)
+		}
+		if len(f.Values) > 0 && (f.Min != nil || f.Max != nil) {
+			return fmt.Errorf("%w: filter on %s cannot have both values and a range", ErrInvalidAttribute, f.Name)
+		}
+	}
+	return nil
+}
+
+// attributeQueries builds one nested query per filter
+func attributeQueries(filters []AttributeFilter) []interface{} {
+	var queries []interface{}
+	for _, f := range filters {
+		must := []interface{}{
+			map[string]interface{}{"term": map[string]interface{}{"attributes.name": f.Name}},
+		}
+		if len(f.Values) > 0 {
+			must = append(must, map[string]interface{}{
+				"terms": map[string]interface{}{"attributes.value": f.Values},
+			})
+		} else {
+			bounds := map[string]interface{}{}
+			if f.Min != nil {
+				bounds["gte"] = *f.Min
+			}
+			if f.Max != nil {
+				bounds["lte"] = *f.Max
+			}
+			must = append(must, map[string]interface{}{
+				"range": map[string]interface{}{"attributes.number": bounds},
+			})
+		}
+
+		queries = append(queries, map[string]interface{}{
+			"nested": map[string]interface{}{
+				"path": "attributes",
+				"query": map[string]interface{}{
+					"bool": map[string]interface{}{"filter": must},
+				},
+			},
+		})
+	}
+	return queries
+}
+
+// categorySchema returns the schema of category, or nil if it has none
+func (p productService) categorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	schema, err := p.repo.GetCategorySchema(ctx, category)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return schema, err
+}
+
+func (p productService) GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	schema, err := p.repo.GetCategorySchema(ctx, category)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNoCategorySchema
+	}
+	return schema, err
+}
+
+// UpdateCategorySchema replaces the attributes of a category. Products
+// already in the category keep their values until they are next updated.
+func (p productService) UpdateCategorySchema(ctx context.Context, schema CategorySchema) (*CategorySchema, error) {
+	schema, err := schema.Validate()
+	if err != nil {
+		return nil, err
+	}
+	schema.UpdatedAt = time.Now().UTC()
+
+	if err := p.repo.PutCategorySchema(ctx, schema); err != nil {
+		return nil, err
+	}
+
+	log.Printf("UpdateCategorySchema: %s has %d attributes", schema.Category, len(schema.Attributes))
+	return &schema, nil
+}
+
+func (r *elasticRepository) ensureCategorySchemasIndex(ctx context.Context) error {
+	return r.ensureIndex(ctx, categorySchemasIndex, categorySchemasMapping)
+}
+
+// Category schemas are stored under their escaped category name, since
+// document IDs are not escaped by the client
+func (r *elasticRepository) GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	res, err := r.client.Get(
+		categorySchemasIndex,
+		url.PathEscape(category),
+		r.client.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get category schema: %s", res.String())
+	}
+
+	var result struct {
+		Source CategorySchema `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result.Source, nil
+}
+
+func (r *elasticRepository) PutCategorySchema(ctx context.Context, schema CategorySchema) error {
+	docBytes, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Index(
+		categorySchemasIndex,
+		bytes.NewReader(docBytes),
+		r.client.Index.WithDocumentID(url.PathEscape(schema.Category)),
+		r.client.Index.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to store category schema: %s", res.String())
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	var schema CategorySchema
+	err := r.db.QueryRow(ctx, `SELECT schema FROM category_schemas WHERE category = $1`, category).Scan(&schema)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+func (r *postgresRepository) PutCategorySchema(ctx context.Context, schema CategorySchema) error {
+	doc, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO category_schemas (category, schema, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (category) DO UPDATE SET
+			schema = EXCLUDED.schema,
+			updated_at = EXCLUDED.updated_at
+	`, schema.Category, doc, schema.UpdatedAt)
+	return err
+}
@@ -0,0 +1,104 @@
+package product
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var laptopSchema = &CategorySchema{
+	Category: "laptops",
+	Attributes: []AttributeDefinition{
+		{Name: "brand", Type: AttributeText, Required: true},
+		{Name: "screen_size", Type: AttributeNumber, Unit: "in"},
+		{Name: "touchscreen", Type: AttributeBoolean},
+		{Name: "color", Type: AttributeEnum, AllowedValues: []string{"silver", "black"}},
+	},
+}
+
+func TestValidateAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes []ProductAttribute
+		want       []ProductAttribute
+	}{
+		{
+			name:       "required only",
+			attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}},
+			want:       []ProductAttribute{{Name: "brand", Value: "Acme"}},
+		},
+		{
+			name: "normalized in schema order",
+			attributes: []ProductAttribute{
+				{Name: "color", Value: "black"},
+				{Name: "touchscreen", Value: "1"},
+				{Name: "screen_size", Value: " 14.50 "},
+				{Name: "brand", Value: " Acme "},
+			},
+			want: []ProductAttribute{
+				{Name: "brand", Value: "Acme"},
+				{Name: "screen_size", Value: "14.5", Unit: "in"},
+				{Name: "touchscreen", Value: "true"},
+				{Name: "color", Value: "black"},
+			},
+		},
+		{
+			name:       "unit comes from the schema",
+			attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "screen_size", Value: "13", Unit: "cm"}},
+			want:       []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "screen_size", Value: "13", Unit: "in"}},
+		},
+		{
+			name:       "blank optional values are dropped",
+			attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "color", Value: "  "}},
+			want:       []ProductAttribute{{Name: "brand", Value: "Acme"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := laptopSchema.validateAttributes(tt.attributes)
+			if err != nil {
+				t.Fatalf("validateAttributes() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validateAttributes() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAttributesInvalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     *CategorySchema
+		attributes []ProductAttribute
+	}{
+		{name: "missing required", schema: laptopSchema, attributes: []ProductAttribute{{Name: "color", Value: "black"}}},
+		{name: "blank required", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: " "}}},
+		{name: "set twice", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "brand", Value: "Other"}}},
+		{name: "not defined", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "weight", Value: "2"}}},
+		{name: "not a number", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "screen_size", Value: "large"}}},
+		{name: "infinite number", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "screen_size", Value: "Inf"}}},
+		{name: "not a boolean", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "touchscreen", Value: "yes"}}},
+		{name: "not an allowed value", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}, {Name: "color", Value: "Black"}}},
+		{name: "text too long", schema: laptopSchema, attributes: []ProductAttribute{{Name: "brand", Value: strings.Repeat("a", maxAttributeValueLength+1)}}},
+		{name: "category without schema", attributes: []ProductAttribute{{Name: "brand", Value: "Acme"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.schema.validateAttributes(tt.attributes); !errors.Is(err, ErrInvalidAttribute) {
+				t.Errorf("validateAttributes() error = %v, want %v", err, ErrInvalidAttribute)
+			}
+		})
+	}
+}
+
+func TestValidateAttributesWithoutSchema(t *testing.T) {
+	var schema *CategorySchema
+	got, err := schema.validateAttributes(nil)
+	if err != nil || got != nil {
+		t.Errorf("validateAttributes() = %v, %v, want no attributes", got, err)
+	}
+}
@@ -0,0 +1,575 @@
+package product
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+	"github.com/google/uuid"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+
+	importBatchSize    = 500
+	maxReportedErrors  = 1000
+	maxJSONLLineLength = 1 << 20
+	scrollKeepAlive    = time.Minute
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported format, expected csv or jsonl")
+	ErrMissingColumns    = errors.New("csv header must contain name and price columns")
+)
+
+var csvColumns = []string{"id", "name", "description", "category", "price", "currency"}
+
+// ImportRecord is one imported or exported product. Price is a decimal amount
+// of Currency, which defaults to money.DefaultCurrency.
+type ImportRecord struct {
+	ID          string      `json:"id,omitempty"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Category    string      `json:"category"`
+	Price       json.Number `json:"price"`
+	Currency    string      `json:"currency,omitempty"`
+}
+
+func (record ImportRecord) price() (money.Money, error) {
+	currency := money.DefaultCurrency
+	if record.Currency != "" {
+		var err error
+		if currency, err = money.NormalizeCurrency(record.Currency); err != nil {
+			return money.Money{}, err
+		}
+	}
+
+	price, err := money.Parse(record.Price.String(), currency)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("invalid price %q", record.Price)
+	}
+	return validatePrice(price)
+}
+
+type RowError struct {
+	Row     uint64 `json:"row"`
+	Message string `json:"message"`
+}
+
+type ImportResult struct {
+	Imported uint64     `json:"imported"`
+	Failed   uint64     `json:"failed"`
+	Errors   []RowError `json:"errors"`
+}
+
+func (res *ImportResult) fail(row uint64, err error) {
+	res.Failed++
+	if len(res.Errors) < maxReportedErrors {
+		res.Errors = append(res.Errors, RowError{Row: row, Message: err.Error()})
+	}
+}
+
+type importRow struct {
+	row    uint64
+	record ImportRecord
+}
+
+func (p productService) ImportProducts(ctx context.Context, accountId, format string, r io.Reader) (*ImportResult, error) {
+	result := &ImportResult{Errors: []RowError{}}
+	var batch []importRow
+
+	err := readImportRecords(format, r, func(row uint64, record ImportRecord, err error) error {
+		if err == nil {
+			err = validateImportRecord(record)
+		}
+		if err != nil {
+			result.fail(row, err)
+			return nil
+		}
+
+		batch = append(batch, importRow{row: row, record: record})
+		if len(batch) >= importBatchSize {
+			if err := p.importBatch(ctx, accountId, batch, result); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batch) > 0 {
+		if err := p.importBatch(ctx, accountId, batch, result); err != nil {
+			return nil, err
+		}
+	}
+
+	log.Printf("ImportProducts: imported %d products, %d rows failed for account %s", result.Imported, result.Failed, accountId)
+	return result, nil
+}
+
+func (p productService) importBatch(ctx context.Context, accountId string, batch []importRow, result *ImportResult) error {
+	var ids []string
+	for _, row := range batch {
+		if row.record.ID != "" {
+			ids = append(ids, row.record.ID)
+		}
+	}
+
+	existing := map[string]Product{}
+	if len(ids) > 0 {
+		products, err := p.repo.ListProductsWithIds(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, product := range products {
+			existing[product.ID] = product
+		}
+	}
+
+	rules, err := p.screeningRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	schemas := map[string]*CategorySchema{}
+
+	now := time.Now().UTC()
+	var rows []importRow
+	var products []Product
+	for _, row := range batch {
+		price, _ := row.record.price()
+		product := Product{
+			ID:          row.record.ID,
+			Name:        row.record.Name,
+			Description: row.record.Description,
+			Category:    row.record.Category,
+			AccountID:   accountId,
+
+			RegularPrice: price,
+		}
+
+		if current, ok := existing[product.ID]; ok {
+			if current.AccountID != accountId {
+				result.fail(row.row, ErrUnauthorized)
+				continue
+			}
+			if len(current.PriceSchedules) > 0 && current.RegularPrice.Currency != price.Currency {
+				result.fail(row.row, money.ErrCurrencyMismatch)
+				continue
+			}
+			product.Images = current.Images
+			product.ArchivedAt = current.ArchivedAt
+			product.Rating = current.Rating
+			product.ReviewCount = current.ReviewCount
+			product.PriceSchedules = current.PriceSchedules
+			product.Attributes = current.Attributes
+			product.Status = current.Status
+			product.PublishAt = current.PublishAt
+			product.Stock = current.Stock
+			product.Bundle = current.Bundle
+			product.Screening = current.Screening
+			p.reviewEdit(current, &product)
+		} else {
+			if product.ID == "" {
+				product.ID = uuid.New().String()
+			}
+			product.Status = StatusDraft
+		}
+
+		// Records carry no attributes, so the product's current attributes
+		// must still be valid in the category of the row
+		schema, ok := schemas[product.Category]
+		if !ok {
+			if schema, err = p.categorySchema(ctx, product.Category); err != nil {
+				return err
+			}
+			schemas[product.Category] = schema
+		}
+		attributes, err := schema.validateAttributes(product.Attributes)
+		if err != nil {
+			result.fail(row.row, err)
+			continue
+		}
+		product.Attributes = attributes
+
+		product.applyPriceSchedules(now)
+
+		if product.Screening, err = p.screen(ctx, rules, product); err != nil {
+			return err
+		}
+
+		rows = append(rows, row)
+		products = append(products, product)
+	}
+
+	if len(products) == 0 {
+		return nil
+	}
+
+	events := make([]Event, len(products))
+	for i, product := range products {
+		eventType := "product_created"
+		if _, ok := existing[product.ID]; ok {
+			eventType = "product_updated"
+		}
+		events[i] = newProductEvent(eventType, product)
+	}
+
+	itemErrors, err := p.repo.BulkPutProducts(ctx, products, events)
+	if err != nil {
+		return err
+	}
+
+	var repriced []Product
+	for i, product := range products {
+		if itemErrors[i] != nil {
+			result.fail(rows[i].row, itemErrors[i])
+			continue
+		}
+		result.Imported++
+
+		if current, ok := existing[product.ID]; !ok || current.Price != product.Price {
+			repriced = append(repriced, product)
+		}
+	}
+
+	if len(repriced) > 0 {
+		p.recordPrices(ctx, PriceReasonImported, now, repriced...)
+	}
+
+	return nil
+}
+
+func (p productService) ExportProducts(ctx context.Context, accountId, format string, w io.Writer) error {
+	var write func(ImportRecord) error
+	var flush func() error
+
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvColumns); err != nil {
+			return err
+		}
+		write = func(rec ImportRecord) error {
+			return cw.Write([]string{rec.ID, rec.Name, rec.Description, rec.Category, rec.Price.String(), rec.Currency})
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		write = func(rec ImportRecord) error {
+			return enc.Encode(rec)
+		}
+		flush = func() error { return nil }
+	default:
+		return ErrUnsupportedFormat
+	}
+
+	err := p.repo.ScanProductsByAccount(ctx, accountId, func(product Product) error {
+		return write(ImportRecord{
+			ID:          product.ID,
+			Name:        product.Name,
+			Description: product.Description,
+			Category:    product.Category,
+			Price:       json.Number(product.RegularPrice.Decimal()),
+			Currency:    product.RegularPrice.Currency,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return flush()
+}
+
+func validateImportRecord(record ImportRecord) error {
+	if strings.TrimSpace(record.Name) == "" {
+		return errors.New("name is required")
+	}
+	_, err := record.price()
+	return err
+}
+
+// readImportRecords calls fn for every data row. Rows are numbered from 1,
+// not counting the CSV header. Parse errors are passed to fn so that a bad row
+// does not abort the whole import.
+func readImportRecords(format string, r io.Reader, fn func(row uint64, record ImportRecord, err error) error) error {
+	switch format {
+	case FormatCSV:
+		return readCSVRecords(r, fn)
+	case FormatJSONL:
+		return readJSONLRecords(r, fn)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+func readCSVRecords(r io.Reader, fn func(row uint64, record ImportRecord, err error) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return ErrMissingColumns
+	}
+	if _, ok := columns["price"]; !ok {
+		return ErrMissingColumns
+	}
+
+	field := func(fields []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	for row := uint64(1); ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		var record ImportRecord
+		if err == nil {
+			record = ImportRecord{
+				ID:          field(fields, "id"),
+				Name:        field(fields, "name"),
+				Description: field(fields, "description"),
+				Category:    field(fields, "category"),
+				Price:       json.Number(field(fields, "price")),
+				Currency:    field(fields, "currency"),
+			}
+		}
+
+		if err := fn(row, record, err); err != nil {
+			return err
+		}
+	}
+}
+
+func readJSONLRecords(r io.Reader, fn func(row uint64, record ImportRecord, err error) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxJSONLLineLength)
+
+	var row uint64
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row++
+
+		var record ImportRecord
+		err := json.Unmarshal(line, &record)
+		if err := fn(row, record, err); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (r *elasticRepository) BulkPutProducts(ctx context.Context, products []Product, events []Event) ([]error, error) {
+	if len(events) > 0 {
+		return nil, ErrNoOutbox
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, p := range products {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index": catalogAlias,
+				"_id":    p.ID,
+			},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(newProductDocument(p)); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.client.Bulk(
+		&body,
+		r.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("bulk request failed: %s", res.String())
+	}
+
+	var result struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	itemErrors := make([]error, len(products))
+	for i, item := range result.Items {
+		if i >= len(itemErrors) {
+			break
+		}
+		for _, op := range item {
+			if op.Error != nil {
+				itemErrors[i] = fmt.Errorf("%s: %s", op.Error.Type, op.Error.Reason)
+			}
+		}
+	}
+
+	return itemErrors, nil
+}
+
+func (r *elasticRepository) ScanProductsByAccount(ctx context.Context, accountId string, fn func(Product) error) error {
+	return r.scanProducts(ctx, excludeArchived(map[string]interface{}{
+		"term": map[string]interface{}{
+			"accountId": accountId,
+		},
+	}), fn)
+}
+
+// scanProducts calls fn for every product matching query using the scroll API
+func (r *elasticRepository) scanProducts(ctx context.Context, q map[string]interface{}, fn func(Product) error) error {
+	return r.scrollCatalog(ctx, map[string]interface{}{"query": q}, func(hit scrollHit) error {
+		return fn(hit.Source.toProduct(hit.ID))
+	})
+}
+
+// scrollCatalog calls fn for every hit of a catalog search using the scroll
+// API
+func (r *elasticRepository) scrollCatalog(ctx context.Context, query map[string]interface{}, fn func(scrollHit) error) error {
+	return r.scrollIndex(ctx, catalogAlias, query, fn)
+}
+
+// scrollIndex calls fn for every hit of a search of index using the scroll
+// API
+func (r *elasticRepository) scrollIndex(ctx context.Context, index string, query map[string]interface{}, fn func(scrollHit) error) error {
+	return r.scrollHits(ctx, index, query, func(raw json.RawMessage) error {
+		var hit scrollHit
+		if err := json.Unmarshal(raw, &hit); err != nil {
+			return err
+		}
+		return fn(hit)
+	})
+}
+
+// scrollHits is scrollIndex for indices that do not hold products. fn is
+// given each hit as it was returned.
+func (r *elasticRepository) scrollHits(ctx context.Context, index string, query map[string]interface{}, fn func(json.RawMessage) error) error {
+	query["size"] = importBatchSize
+
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(index),
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithScroll(scrollKeepAlive),
+		r.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+
+	var scrollID string
+	defer func() {
+		if scrollID != "" {
+			r.client.ClearScroll(r.client.ClearScroll.WithScrollID(scrollID))
+		}
+	}()
+
+	for {
+		page, err := decodeScrollPage(res)
+		if err != nil {
+			return err
+		}
+		scrollID = page.ScrollID
+
+		if len(page.Hits.Hits) == 0 {
+			return nil
+		}
+
+		for _, hit := range page.Hits.Hits {
+			if err := fn(hit); err != nil {
+				return err
+			}
+		}
+
+		res, err = r.client.Scroll(
+			r.client.Scroll.WithScrollID(scrollID),
+			r.client.Scroll.WithScroll(scrollKeepAlive),
+			r.client.Scroll.WithContext(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type scrollHit struct {
+	ID          string          `json:"_id"`
+	Version     int64           `json:"_version"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	Source      ProductDocument `json:"_source"`
+}
+
+type scrollPage struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []json.RawMessage `json:"hits"`
+	} `json:"hits"`
+}
+
+func decodeScrollPage(res *esapi.Response) (*scrollPage, error) {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("scroll request failed: %s", res.String())
+	}
+
+	var page scrollPage
+	if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
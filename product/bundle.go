@@ -0,0 +1,281 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const maxBundleComponents = 20
+
+var (
+	ErrInvalidBundle = errors.New("bundle components must be distinct active products of the seller that are not bundles themselves")
+	ErrBundleNested  = errors.New("product is a component of another bundle")
+	ErrInvalidStock  = errors.New("stock must not be negative")
+	ErrBundleStock   = errors.New("bundle stock is derived from its components")
+
+	ErrInsufficientStock   = errors.New("not enough stock")
+	ErrNoStockReservations = errors.New("stock is only reserved by the Postgres repository")
+)
+
+// StockLine is a quantity of a product taken from or returned to stock
+type StockLine struct {
+	ProductID string
+	Quantity  uint32
+}
+
+// StockRepository changes the stock of products
+type StockRepository interface {
+	// AdjustStock adds changes to the stock of the products that track it,
+	// in one write. It fails with ErrInsufficientStock, changing nothing, if
+	// any stock would become negative.
+	AdjustStock(ctx context.Context, changes map[string]int64) error
+	// SetStock replaces the stock of a product, or stops tracking it when
+	// stock is nil, and records events in the same write
+	SetStock(ctx context.Context, productId string, stock *int64, events ...Event) error
+}
+
+// BundleComponent is a product sold as part of a bundle, Quantity units per
+// bundle
+type BundleComponent struct {
+	ProductID string `json:"productId"`
+	Quantity  uint32 `json:"quantity"`
+}
+
+// IsBundle reports whether the product is sold as a kit of other products.
+// A bundle has its own price, and orders for it are fulfilled from its
+// components.
+func (p Product) IsBundle() bool {
+	return len(p.Bundle) > 0
+}
+
+// SetBundle makes a product a bundle of components, or a regular product
+// again when components is empty. Stock set on the product is dropped, as a
+// bundle's stock is derived from its components.
+func (p productService) SetBundle(ctx context.Context, productId, accountId string, components []BundleComponent) (*Product, error) {
+	if len(components) > maxBundleComponents {
+		return nil, ErrInvalidBundle
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	if len(components) > 0 {
+		if err := p.validateBundle(ctx, *product, components); err != nil {
+			return nil, err
+		}
+	}
+
+	product.Bundle = components
+	product.Stock = nil
+
+	product.Version, err = p.repo.UpdateProduct(ctx, *product, newProductEvent("product_updated", *product))
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := p.deriveBundleStock(ctx, []Product{*product})
+	if err != nil {
+		return nil, err
+	}
+	return &products[0], nil
+}
+
+// validateBundle checks that components are distinct active products of the
+// bundle's seller and that bundles are only one level deep
+func (p productService) validateBundle(ctx context.Context, bundle Product, components []BundleComponent) error {
+	ids := make([]string, len(components))
+	seen := map[string]bool{}
+	for i, c := range components {
+		if c.Quantity == 0 || c.ProductID == bundle.ID || seen[c.ProductID] {
+			return ErrInvalidBundle
+		}
+		seen[c.ProductID] = true
+		ids[i] = c.ProductID
+	}
+
+	found, err := p.repo.ListProductsWithIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	if len(found) != len(ids) {
+		return ErrInvalidBundle
+	}
+	for _, c := range found {
+		if c.AccountID != bundle.AccountID || c.ArchivedAt != nil || c.IsBundle() {
+			return ErrInvalidBundle
+		}
+	}
+
+	containing, err := p.repo.ListBundlesContaining(ctx, bundle.ID)
+	if err != nil {
+		return err
+	}
+	if len(containing) > 0 {
+		return ErrBundleNested
+	}
+	return nil
+}
+
+// SetStock sets how many units of a product can be ordered, or stops
+// tracking its stock when stock is nil
+func (p productService) SetStock(ctx context.Context, productId, accountId string, stock *int64) (*Product, error) {
+	if stock != nil && *stock < 0 {
+		return nil, ErrInvalidStock
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+	if product.IsBundle() {
+		return nil, ErrBundleStock
+	}
+
+	product.Stock = stock
+	if p.stock != nil {
+		// Product updates keep the stored stock, so they cannot undo orders
+		// placed since the product was read
+		if err := p.stock.SetStock(ctx, productId, stock, newProductEvent("product_updated", *product)); err != nil {
+			return nil, err
+		}
+		return product, nil
+	}
+	product.Version, err = p.repo.UpdateProduct(ctx, *product, newProductEvent("product_updated", *product))
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ReserveStock takes ordered quantities from stock, or fails with
+// ErrInsufficientStock without taking any. Bundles take the stock of their
+// components, and products that do not track stock or no longer exist are
+// left alone.
+func (p productService) ReserveStock(ctx context.Context, lines []StockLine) error {
+	return p.adjustStock(ctx, lines, -1)
+}
+
+// ReleaseStock returns quantities taken by ReserveStock to stock
+func (p productService) ReleaseStock(ctx context.Context, lines []StockLine) error {
+	return p.adjustStock(ctx, lines, 1)
+}
+
+func (p productService) adjustStock(ctx context.Context, lines []StockLine, sign int64) error {
+	if p.stock == nil {
+		return ErrNoStockReservations
+	}
+
+	ids := make([]string, len(lines))
+	for i, line := range lines {
+		ids[i] = line.ProductID
+	}
+	found, err := p.repo.ListProductsWithIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	products := map[string]Product{}
+	for _, product := range found {
+		products[product.ID] = product
+	}
+
+	changes := map[string]int64{}
+	for _, line := range lines {
+		product, ok := products[line.ProductID]
+		if !ok {
+			continue
+		}
+		if !product.IsBundle() {
+			changes[product.ID] += sign * int64(line.Quantity)
+			continue
+		}
+		for _, c := range product.Bundle {
+			changes[c.ProductID] += sign * int64(line.Quantity) * int64(c.Quantity)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return p.stock.AdjustStock(ctx, changes)
+}
+
+// deriveBundleStock sets the stock of the bundles among products to the
+// number of complete bundles their components allow. Components that do not
+// track stock do not limit it, and a component that cannot be sold makes the
+// bundle unavailable. A bundle of untracked components is untracked too.
+func (p productService) deriveBundleStock(ctx context.Context, products []Product) ([]Product, error) {
+	var ids []string
+	for _, product := range products {
+		for _, c := range product.Bundle {
+			ids = append(ids, c.ProductID)
+		}
+	}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	found, err := p.repo.ListProductsWithIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	components := map[string]Product{}
+	for _, c := range found {
+		components[c.ID] = c
+	}
+
+	now := time.Now()
+	for i, product := range products {
+		if !product.IsBundle() {
+			continue
+		}
+
+		var stock *int64
+		for _, c := range product.Bundle {
+			component, ok := components[c.ProductID]
+			var available int64
+			switch {
+			case !ok || !component.IsPublished(now):
+				available = 0
+			case component.Stock == nil:
+				continue
+			default:
+				available = *component.Stock / int64(c.Quantity)
+			}
+			if stock == nil || available < *stock {
+				stock = &available
+			}
+		}
+		products[i].Stock = stock
+	}
+	return products, nil
+}
+
+// ListBundlesContaining returns the IDs of the bundles that have productId
+// as a component
+func (r *elasticRepository) ListBundlesContaining(ctx context.Context, productId string) ([]string, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"bundle.productId": productId},
+		},
+		"size":    maxPageSize,
+		"_source": []string{"accountId"},
+	}
+
+	products, err := r.searchCatalog(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,210 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// stockRepository serves products by ID and records the changes passed to
+// AdjustStock. Every other method panics.
+type stockRepository struct {
+	Repository
+	StockRepository
+	products map[string]Product
+	changes  map[string]int64
+	adjusted bool
+}
+
+func (r *stockRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]Product, error) {
+	var products []Product
+	for _, id := range ids {
+		if p, ok := r.products[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (r *stockRepository) AdjustStock(ctx context.Context, changes map[string]int64) error {
+	r.changes = changes
+	r.adjusted = true
+	return nil
+}
+
+func newStockRepository(products ...Product) *stockRepository {
+	r := &stockRepository{products: map[string]Product{}}
+	for _, p := range products {
+		r.products[p.ID] = p
+	}
+	return r
+}
+
+func stock(n int64) *int64 {
+	return &n
+}
+
+func TestAdjustStock(t *testing.T) {
+	repo := newStockRepository(
+		Product{ID: "charger", Status: StatusPublished, Stock: stock(40)},
+		Product{ID: "cable", Status: StatusPublished, Stock: stock(100)},
+		Product{ID: "poster", Status: StatusPublished},
+		Product{ID: "kit", Status: StatusPublished, Bundle: []BundleComponent{
+			{ProductID: "charger", Quantity: 1},
+			{ProductID: "cable", Quantity: 2},
+		}},
+	)
+
+	tests := []struct {
+		name  string
+		lines []StockLine
+		sign  int64
+		want  map[string]int64
+	}{
+		{
+			name:  "reserve products",
+			lines: []StockLine{{ProductID: "charger", Quantity: 2}, {ProductID: "cable", Quantity: 1}},
+			sign:  -1,
+			want:  map[string]int64{"charger": -2, "cable": -1},
+		},
+		{
+			name:  "release products",
+			lines: []StockLine{{ProductID: "charger", Quantity: 2}},
+			sign:  1,
+			want:  map[string]int64{"charger": 2},
+		},
+		{
+			name:  "bundle takes its components",
+			lines: []StockLine{{ProductID: "kit", Quantity: 3}},
+			sign:  -1,
+			want:  map[string]int64{"charger": -3, "cable": -6},
+		},
+		{
+			name:  "bundle and component in one order",
+			lines: []StockLine{{ProductID: "kit", Quantity: 1}, {ProductID: "cable", Quantity: 1}},
+			sign:  -1,
+			want:  map[string]int64{"charger": -1, "cable": -3},
+		},
+		{
+			name:  "untracked products are passed on",
+			lines: []StockLine{{ProductID: "poster", Quantity: 1}},
+			sign:  -1,
+			want:  map[string]int64{"poster": -1},
+		},
+		{
+			name:  "missing products are skipped",
+			lines: []StockLine{{ProductID: "deleted", Quantity: 1}, {ProductID: "charger", Quantity: 1}},
+			sign:  -1,
+			want:  map[string]int64{"charger": -1},
+		},
+		{
+			name:  "only missing products",
+			lines: []StockLine{{ProductID: "deleted", Quantity: 1}},
+			sign:  -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.changes, repo.adjusted = nil, false
+			service := productService{repo: repo, stock: repo}
+
+			if err := service.adjustStock(context.Background(), tt.lines, tt.sign); err != nil {
+				t.Fatalf("adjustStock() error = %v", err)
+			}
+			if tt.want == nil {
+				if repo.adjusted {
+					t.Errorf("AdjustStock called with %v, want no call", repo.changes)
+				}
+				return
+			}
+			if !reflect.DeepEqual(repo.changes, tt.want) {
+				t.Errorf("AdjustStock() changes = %v, want %v", repo.changes, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustStockWithoutStockRepository(t *testing.T) {
+	service := productService{repo: newStockRepository()}
+	err := service.ReserveStock(context.Background(), []StockLine{{ProductID: "charger", Quantity: 1}})
+	if !errors.Is(err, ErrNoStockReservations) {
+		t.Errorf("ReserveStock() error = %v, want %v", err, ErrNoStockReservations)
+	}
+}
+
+func TestDeriveBundleStock(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	repo := newStockRepository(
+		Product{ID: "charger", Status: StatusPublished, Stock: stock(40)},
+		Product{ID: "cable", Status: StatusPublished, Stock: stock(9)},
+		Product{ID: "poster", Status: StatusPublished},
+		Product{ID: "sticker", Status: StatusPublished},
+		Product{ID: "draft", Status: StatusDraft, Stock: stock(100)},
+		Product{ID: "scheduled", Status: StatusPublished, PublishAt: &future, Stock: stock(100)},
+	)
+	service := productService{repo: repo}
+
+	tests := []struct {
+		name       string
+		components []BundleComponent
+		want       *int64
+	}{
+		{
+			name:       "limited by the scarcest component",
+			components: []BundleComponent{{ProductID: "charger", Quantity: 1}, {ProductID: "cable", Quantity: 2}},
+			want:       stock(4),
+		},
+		{
+			name:       "untracked components do not limit",
+			components: []BundleComponent{{ProductID: "charger", Quantity: 10}, {ProductID: "poster", Quantity: 1}},
+			want:       stock(4),
+		},
+		{
+			name:       "untracked components only",
+			components: []BundleComponent{{ProductID: "poster", Quantity: 1}, {ProductID: "sticker", Quantity: 5}},
+		},
+		{
+			name:       "missing component",
+			components: []BundleComponent{{ProductID: "charger", Quantity: 1}, {ProductID: "deleted", Quantity: 1}},
+			want:       stock(0),
+		},
+		{
+			name:       "unpublished component",
+			components: []BundleComponent{{ProductID: "poster", Quantity: 1}, {ProductID: "draft", Quantity: 1}},
+			want:       stock(0),
+		},
+		{
+			name:       "component published later",
+			components: []BundleComponent{{ProductID: "scheduled", Quantity: 1}},
+			want:       stock(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := Product{ID: "kit", Status: StatusPublished, Bundle: tt.components, Stock: stock(1000)}
+			single := Product{ID: "charger", Status: StatusPublished, Stock: stock(40)}
+
+			got, err := service.deriveBundleStock(context.Background(), []Product{single, bundle})
+			if err != nil {
+				t.Fatalf("deriveBundleStock() error = %v", err)
+			}
+			if !reflect.DeepEqual(got[1].Stock, tt.want) {
+				t.Errorf("bundle stock = %v, want %v", formatStock(got[1].Stock), formatStock(tt.want))
+			}
+			if *got[0].Stock != 40 {
+				t.Errorf("product stock = %d, want it unchanged", *got[0].Stock)
+			}
+		})
+	}
+}
+
+func formatStock(stock *int64) interface{} {
+	if stock == nil {
+		return "untracked"
+	}
+	return *stock
+}
@@ -2,10 +2,18 @@ package product
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"github.com/go-systems-lab/go-ecommerce-lld/product/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type Client struct {
@@ -31,11 +39,13 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) PostProduct(ctx context.Context, name, description string, price float64, accountId string) (*Product, error) {
+func (c *Client) PostProduct(ctx context.Context, name, description, category string, price money.Money, attributes []ProductAttribute, accountId string) (*Product, error) {
 	r, err := c.service.PostProduct(ctx, &pb.CreateProductRequest{
 		Name:        name,
 		Description: description,
-		Price:       price,
+		Category:    category,
+		Price:       toProtoMoney(price),
+		Attributes:  toProtoAttributes(attributes),
 		AccountId:   accountId,
 	})
 
@@ -43,13 +53,7 @@ func (c *Client) PostProduct(ctx context.Context, name, description string, pric
 		return nil, err
 	}
 
-	return &Product{
-		ID:          r.Product.Id,
-		Name:        r.Product.Name,
-		Description: r.Product.Description,
-		Price:       r.Product.Price,
-		AccountID:   r.Product.GetAccountId(),
-	}, nil
+	return fromProtoProduct(r.Product), nil
 }
 
 func (c *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
@@ -58,16 +62,10 @@ func (c *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, fromProductStatus(err)
 	}
 
-	return &Product{
-		ID:          r.Product.Id,
-		Name:        r.Product.Name,
-		Description: r.Product.Description,
-		Price:       r.Product.Price,
-		AccountID:   r.Product.GetAccountId(),
-	}, nil
+	return fromProtoProduct(r.Product), nil
 }
 
 func (c *Client) GetProducts(ctx context.Context, skip, take uint64, ids []string, query string) ([]Product, error) {
@@ -84,36 +82,218 @@ func (c *Client) GetProducts(ctx context.Context, skip, take uint64, ids []strin
 
 	var products []Product
 	for _, p := range r.Products {
-		products = append(products, Product{
-			ID:          p.Id,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			AccountID:   p.GetAccountId(),
+		products = append(products, *fromProtoProduct(p))
+	}
+
+	return products, nil
+}
+
+// ListProductsByAccount lists a seller's own products, including drafts
+// and archived products
+func (c *Client) ListProductsByAccount(ctx context.Context, accountId string, statuses []string, archived *bool, skip, take uint64) ([]Product, error) {
+	r, err := c.service.ListProductsByAccount(ctx, &pb.ListProductsByAccountRequest{
+		AccountId: accountId,
+		Statuses:  statuses,
+		Archived:  archived,
+		Skip:      skip,
+		Take:      take,
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return nil, ErrInvalidStatus
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var products []Product
+	for _, p := range r.Products {
+		products = append(products, *fromProtoProduct(p))
+	}
+	return products, nil
+}
+
+// SearchProducts runs a full-text search, optionally filtered by minimum
+// rating and attributes and sorted by SortRating instead of relevance
+func (c *Client) SearchProducts(ctx context.Context, query string, skip, take uint64, minRating float64, sort string, attributes []AttributeFilter) ([]Product, error) {
+	r, err := c.service.GetProducts(ctx, &pb.GetProductsRequest{
+		Skip:       skip,
+		Take:       take,
+		Query:      query,
+		MinRating:  minRating,
+		Sort:       sort,
+		Attributes: toProtoAttributeFilters(attributes),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var products []Product
+	for _, p := range r.Products {
+		products = append(products, *fromProtoProduct(p))
+	}
+
+	return products, nil
+}
+
+// SearchProductsPage is SearchProducts paginated with cursors. Pass an empty
+// cursor for the first page, then a cursor from the previous page.
+func (c *Client) SearchProductsPage(ctx context.Context, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error) {
+	r, err := c.service.GetProducts(ctx, &pb.GetProductsRequest{
+		Take:       take,
+		Query:      query,
+		MinRating:  minRating,
+		Sort:       sort,
+		Attributes: toProtoAttributeFilters(attributes),
+		Cursor:     cursor,
+		WithCursor: true,
+	})
+	if err != nil {
+		return nil, fromProductStatus(err)
+	}
+
+	page := &ProductPage{Cursors: r.Cursors, HasMore: r.HasMore}
+	for _, p := range r.Products {
+		page.Products = append(page.Products, *fromProtoProduct(p))
+	}
+	return page, nil
+}
+
+func (c *Client) SuggestProducts(ctx context.Context, prefix string, size int) (*Suggestions, error) {
+	res, err := c.service.SuggestProducts(ctx, &pb.SuggestProductsRequest{
+		Prefix: prefix,
+		Size:   uint32(size),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := &Suggestions{
+		Products:   []ProductSuggestion{},
+		Categories: res.Categories,
+	}
+	for _, p := range res.Products {
+		suggestions.Products = append(suggestions.Products, ProductSuggestion{
+			ID:   p.Id,
+			Name: p.Name,
 		})
 	}
 
+	return suggestions, nil
+}
+
+func (c *Client) GetRelatedProducts(ctx context.Context, productId string, size int) ([]Product, error) {
+	res, err := c.service.GetRelatedProducts(ctx, &pb.GetRelatedProductsRequest{
+		ProductId: productId,
+		Size:      uint32(size),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	products := []Product{}
+	for _, p := range res.Products {
+		products = append(products, *fromProtoProduct(p))
+	}
 	return products, nil
 }
 
-func (c *Client) UpdateProduct(ctx context.Context, id, name, description string, price float64, accountId string) (*Product, error) {
-	res, err := c.service.UpdateProduct(ctx, &pb.UpdateProductRequest{
-		Id:          id,
-		Name:        name,
-		Description: description,
-		Price:       price,
-		AccountId:   accountId,
+func (c *Client) GetSearchSynonyms(ctx context.Context) ([]string, error) {
+	res, err := c.service.GetSearchSynonyms(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Rules, nil
+}
+
+func (c *Client) UpdateSearchSynonyms(ctx context.Context, rules []string) ([]string, error) {
+	res, err := c.service.UpdateSearchSynonyms(ctx, &pb.UpdateSearchSynonymsRequest{
+		Rules: rules,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		res.Product.Id,
-		res.Product.Name,
-		res.Product.Description,
-		res.Product.Price,
-		res.Product.GetAccountId(),
-	}, nil
+	return res.Rules, nil
+}
+
+// GetExchangeRates returns ErrNoExchangeRates until rates have been loaded
+func (c *Client) GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error) {
+	res, err := c.service.GetExchangeRates(ctx, &emptypb.Empty{})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNoExchangeRates
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoExchangeRates(res.Rates), nil
+}
+
+func (c *Client) UpdateExchangeRates(ctx context.Context, rates money.ExchangeRates) (*money.ExchangeRates, error) {
+	res, err := c.service.UpdateExchangeRates(ctx, &pb.UpdateExchangeRatesRequest{
+		Rates: &pb.ExchangeRates{Base: rates.Base, Rates: rates.Rates},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoExchangeRates(res.Rates), nil
+}
+
+func fromProtoExchangeRates(r *pb.ExchangeRates) *money.ExchangeRates {
+	rates := &money.ExchangeRates{Base: r.GetBase(), Rates: r.GetRates()}
+	rates.UpdatedAt.UnmarshalBinary(r.GetUpdatedAt())
+	return rates
+}
+
+// GetCategorySchema returns ErrNoCategorySchema for categories without
+// attributes
+func (c *Client) GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error) {
+	res, err := c.service.GetCategorySchema(ctx, &pb.GetCategorySchemaRequest{Category: category})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNoCategorySchema
+	}
+	if err != nil {
+		return nil, err
+	}
+	schema := fromProtoCategorySchema(res.Schema)
+	return &schema, nil
+}
+
+func (c *Client) UpdateCategorySchema(ctx context.Context, schema CategorySchema) (*CategorySchema, error) {
+	res, err := c.service.UpdateCategorySchema(ctx, &pb.UpdateCategorySchemaRequest{
+		Schema: toProtoCategorySchema(&schema),
+	})
+	if err != nil {
+		return nil, err
+	}
+	updated := fromProtoCategorySchema(res.Schema)
+	return &updated, nil
+}
+
+func (c *Client) UpdateProduct(ctx context.Context, id, name, description, category string, price money.Money, attributes []ProductAttribute, accountId, expectedVersion string, paths []string) (*Product, error) {
+	req := &pb.UpdateProductRequest{
+		Id:              id,
+		Name:            name,
+		Description:     description,
+		Category:        category,
+		Price:           toProtoMoney(price),
+		Attributes:      toProtoAttributes(attributes),
+		AccountId:       accountId,
+		ExpectedVersion: expectedVersion,
+	}
+	if len(paths) > 0 {
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: paths}
+	}
+
+	res, err := c.service.UpdateProduct(ctx, req)
+	if status.Code(err) == codes.Aborted {
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
 }
 
 func (c *Client) DeleteProduct(ctx context.Context, id string, accountId string) error {
@@ -123,3 +303,854 @@ func (c *Client) DeleteProduct(ctx context.Context, id string, accountId string)
 	})
 	return err
 }
+
+// PublishProduct lists a product, at publishAt if it is set and in the
+// future. It returns ErrInvalidStatusTransition for archived products.
+func (c *Client) PublishProduct(ctx context.Context, id, accountId string, publishAt *time.Time) (*Product, error) {
+	req := &pb.PublishProductRequest{
+		ProductId: id,
+		AccountId: accountId,
+	}
+	if publishAt != nil {
+		req.PublishAt, _ = publishAt.MarshalBinary()
+	}
+
+	res, err := c.service.PublishProduct(ctx, req)
+	if err != nil {
+		return nil, fromProductStatus(err)
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) UnpublishProduct(ctx context.Context, id, accountId string) (*Product, error) {
+	res, err := c.service.UnpublishProduct(ctx, &pb.UnpublishProductRequest{
+		ProductId: id,
+		AccountId: accountId,
+	})
+	if err != nil {
+		return nil, fromProductStatus(err)
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+// ReviewProduct approves or rejects a product waiting for review. adminId
+// is recorded as the author of the change.
+func (c *Client) ReviewProduct(ctx context.Context, id, adminId string, approve bool) (*Product, error) {
+	res, err := c.service.ReviewProduct(ctx, &pb.ReviewProductRequest{
+		ProductId: id,
+		Approve:   approve,
+		AccountId: adminId,
+	})
+	if err != nil {
+		return nil, fromProductStatus(err)
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) RestoreProduct(ctx context.Context, id string, accountId string) (*Product, error) {
+	res, err := c.service.RestoreProduct(ctx, &pb.RestoreProductRequest{
+		ProductId: id,
+		AccountId: accountId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) PurgeArchivedProducts(ctx context.Context, retention time.Duration) (uint64, error) {
+	res, err := c.service.PurgeArchivedProducts(ctx, &pb.PurgeArchivedProductsRequest{
+		RetentionSeconds: int64(retention / time.Second),
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.Purged, nil
+}
+
+func (c *Client) UploadProductImage(ctx context.Context, productId, accountId, filename string, r io.Reader) (*Product, error) {
+	stream, err := c.service.UploadProductImage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&pb.UploadProductImageRequest{
+		Data: &pb.UploadProductImageRequest_Info{
+			Info: &pb.ImageUploadInfo{
+				ProductId: productId,
+				AccountId: accountId,
+				Filename:  filename,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&pb.UploadProductImageRequest{
+				Data: &pb.UploadProductImageRequest_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				return nil, sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) ImportProducts(ctx context.Context, accountId, format string, r io.Reader) (*ImportResult, error) {
+	stream, err := c.service.ImportProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = stream.Send(&pb.ImportProductsRequest{
+		Data: &pb.ImportProductsRequest_Options{
+			Options: &pb.ImportOptions{
+				AccountId: accountId,
+				Format:    format,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&pb.ImportProductsRequest{
+				Data: &pb.ImportProductsRequest_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				return nil, sendErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Imported: res.Imported,
+		Failed:   res.Failed,
+		Errors:   []RowError{},
+	}
+	for _, rowErr := range res.Errors {
+		result.Errors = append(result.Errors, RowError{
+			Row:     rowErr.Row,
+			Message: rowErr.Message,
+		})
+	}
+
+	return result, nil
+}
+
+func (c *Client) ExportProducts(ctx context.Context, accountId, format string, w io.Writer) error {
+	stream, err := c.service.ExportProducts(ctx, &pb.ExportProductsRequest{
+		AccountId: accountId,
+		Format:    format,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(res.Chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) RemoveProductImage(ctx context.Context, productId, imageId, accountId string) (*Product, error) {
+	res, err := c.service.RemoveProductImage(ctx, &pb.RemoveProductImageRequest{
+		ProductId: productId,
+		ImageId:   imageId,
+		AccountId: accountId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) ReorderProductImages(ctx context.Context, productId, accountId string, imageIds []string) (*Product, error) {
+	res, err := c.service.ReorderProductImages(ctx, &pb.ReorderProductImagesRequest{
+		ProductId: productId,
+		AccountId: accountId,
+		ImageIds:  imageIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) PostReview(ctx context.Context, productId, accountId string, rating int, title, body string, verifiedPurchase bool) (*Review, error) {
+	res, err := c.service.PostReview(ctx, &pb.PostReviewRequest{
+		ProductId:        productId,
+		AccountId:        accountId,
+		Rating:           int32(rating),
+		Title:            title,
+		Body:             body,
+		VerifiedPurchase: verifiedPurchase,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return nil, ErrReviewExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoReview(res.Review), nil
+}
+
+func (c *Client) GetProductReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error) {
+	res, err := c.service.GetProductReviews(ctx, &pb.GetProductReviewsRequest{
+		ProductId: productId,
+		Skip:      skip,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []Review{}
+	for _, r := range res.Reviews {
+		reviews = append(reviews, *fromProtoReview(r))
+	}
+	return reviews, nil
+}
+
+func (c *Client) ReplyToReview(ctx context.Context, reviewId, accountId, body string) (*Review, error) {
+	res, err := c.service.ReplyToReview(ctx, &pb.ReplyToReviewRequest{
+		ReviewId:  reviewId,
+		AccountId: accountId,
+		Body:      body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoReview(res.Review), nil
+}
+
+func (c *Client) VoteReviewHelpful(ctx context.Context, reviewId, accountId string) (*Review, error) {
+	res, err := c.service.VoteReviewHelpful(ctx, &pb.VoteReviewHelpfulRequest{
+		ReviewId:  reviewId,
+		AccountId: accountId,
+	})
+	if status.Code(err) == codes.Aborted {
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoReview(res.Review), nil
+}
+
+func (c *Client) ModerateReview(ctx context.Context, reviewId, reviewStatus string) (*Review, error) {
+	res, err := c.service.ModerateReview(ctx, &pb.ModerateReviewRequest{
+		ReviewId: reviewId,
+		Status:   reviewStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoReview(res.Review), nil
+}
+
+func (c *Client) SchedulePrice(ctx context.Context, productId, accountId string, price money.Money, startsAt, endsAt time.Time) (*Product, error) {
+	req := &pb.SchedulePriceRequest{
+		ProductId: productId,
+		AccountId: accountId,
+		Price:     toProtoMoney(price),
+	}
+	req.StartsAt, _ = startsAt.MarshalBinary()
+	req.EndsAt, _ = endsAt.MarshalBinary()
+
+	res, err := c.service.SchedulePrice(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) CancelPriceSchedule(ctx context.Context, productId, scheduleId, accountId string) (*Product, error) {
+	res, err := c.service.CancelPriceSchedule(ctx, &pb.CancelPriceScheduleRequest{
+		ProductId:  productId,
+		AccountId:  accountId,
+		ScheduleId: scheduleId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+// SetBundle makes a product a bundle of components, or a regular product
+// again when components is empty
+func (c *Client) SetBundle(ctx context.Context, productId, accountId string, components []BundleComponent) (*Product, error) {
+	req := &pb.SetBundleRequest{
+		ProductId: productId,
+		AccountId: accountId,
+	}
+	for _, component := range components {
+		req.Components = append(req.Components, &pb.BundleComponent{ProductId: component.ProductID, Quantity: component.Quantity})
+	}
+
+	res, err := c.service.SetBundle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+// SetStock sets the stock of a product, or stops tracking it when stock is
+// nil
+func (c *Client) SetStock(ctx context.Context, productId, accountId string, stock *int64) (*Product, error) {
+	res, err := c.service.SetStock(ctx, &pb.SetStockRequest{
+		ProductId: productId,
+		AccountId: accountId,
+		Stock:     stock,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+// ReserveStock takes ordered quantities from stock, or fails with
+// ErrInsufficientStock without taking any
+func (c *Client) ReserveStock(ctx context.Context, lines []StockLine) error {
+	_, err := c.service.ReserveStock(ctx, toProtoStockRequest(lines))
+	return fromProductStatus(err)
+}
+
+// ReleaseStock returns quantities taken by ReserveStock to stock
+func (c *Client) ReleaseStock(ctx context.Context, lines []StockLine) error {
+	_, err := c.service.ReleaseStock(ctx, toProtoStockRequest(lines))
+	return fromProductStatus(err)
+}
+
+func toProtoStockRequest(lines []StockLine) *pb.StockRequest {
+	req := &pb.StockRequest{}
+	for _, line := range lines {
+		req.Lines = append(req.Lines, &pb.StockLine{ProductId: line.ProductID, Quantity: line.Quantity})
+	}
+	return req
+}
+
+func (c *Client) GetPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error) {
+	res, err := c.service.GetPriceHistory(ctx, &pb.GetPriceHistoryRequest{
+		ProductId: productId,
+		Skip:      skip,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := []PricePoint{}
+	for _, pp := range res.Prices {
+		point := PricePoint{
+			ProductID:      productId,
+			Price:          fromProtoMoney(pp.Price),
+			CompareAtPrice: fromProtoMoney(pp.CompareAtPrice),
+			Reason:         pp.Reason,
+		}
+		point.ChangedAt.UnmarshalBinary(pp.ChangedAt)
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func fromProtoReview(r *pb.Review) *Review {
+	review := &Review{
+		ID:               r.Id,
+		ProductID:        r.ProductId,
+		AccountID:        r.AccountId,
+		Rating:           int(r.Rating),
+		Title:            r.Title,
+		Body:             r.Body,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           r.Status,
+		HelpfulVotes:     int(r.HelpfulVotes),
+	}
+	review.CreatedAt.UnmarshalBinary(r.CreatedAt)
+	if r.Reply != nil {
+		review.Reply = &SellerReply{Body: r.Reply.Body}
+		review.Reply.CreatedAt.UnmarshalBinary(r.Reply.CreatedAt)
+	}
+	return review
+}
+
+func fromProtoProduct(p *pb.Product) *Product {
+	product := &Product{
+		ID:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Category:    p.Category,
+		Price:       fromProtoMoney(p.GetPrice()),
+		AccountID:   p.GetAccountId(),
+		Version:     p.GetVersion(),
+		Rating:      p.GetRating(),
+		ReviewCount: int(p.GetReviewCount()),
+
+		RegularPrice: fromProtoMoney(p.GetRegularPrice()),
+		Attributes:   fromProtoAttributes(p.GetAttributes()),
+		Status:       p.GetStatus(),
+		Stock:        p.Stock,
+	}
+	for _, c := range p.GetBundle() {
+		product.Bundle = append(product.Bundle, BundleComponent{ProductID: c.GetProductId(), Quantity: c.GetQuantity()})
+	}
+	if s := p.GetScreening(); s != nil {
+		product.Screening = &Screening{
+			Status:  s.GetStatus(),
+			Reasons: s.GetReasons(),
+			Note:    s.GetNote(),
+		}
+		product.Screening.FlaggedAt.UnmarshalBinary(s.GetFlaggedAt())
+		if len(s.GetReviewedAt()) > 0 {
+			var reviewedAt time.Time
+			if err := reviewedAt.UnmarshalBinary(s.GetReviewedAt()); err == nil {
+				product.Screening.ReviewedAt = &reviewedAt
+			}
+		}
+	}
+	for _, ps := range p.PriceSchedules {
+		schedule := PriceSchedule{ID: ps.Id, Price: fromProtoMoney(ps.GetPrice())}
+		schedule.StartsAt.UnmarshalBinary(ps.StartsAt)
+		schedule.EndsAt.UnmarshalBinary(ps.EndsAt)
+		product.PriceSchedules = append(product.PriceSchedules, schedule)
+	}
+	if len(p.ArchivedAt) > 0 {
+		var archivedAt time.Time
+		if err := archivedAt.UnmarshalBinary(p.ArchivedAt); err == nil {
+			product.ArchivedAt = &archivedAt
+		}
+	}
+	if len(p.PublishAt) > 0 {
+		var publishAt time.Time
+		if err := publishAt.UnmarshalBinary(p.PublishAt); err == nil {
+			product.PublishAt = &publishAt
+		}
+	}
+
+	for _, img := range p.Images {
+		image := ProductImage{
+			ID:       img.Id,
+			URL:      img.Url,
+			Width:    int(img.Width),
+			Height:   int(img.Height),
+			Position: int(img.Position),
+		}
+		for _, t := range img.Thumbnails {
+			image.Thumbnails = append(image.Thumbnails, Thumbnail{
+				Size:   t.Size,
+				URL:    t.Url,
+				Width:  int(t.Width),
+				Height: int(t.Height),
+			})
+		}
+		product.Images = append(product.Images, image)
+	}
+
+	return product
+}
+
+// ListWishlists returns the wishlists of an account, only the public ones
+// with publicOnly
+func (c *Client) ListWishlists(ctx context.Context, accountId string, publicOnly bool) ([]Wishlist, error) {
+	res, err := c.service.ListWishlists(ctx, &pb.ListWishlistsRequest{
+		AccountId:  accountId,
+		PublicOnly: publicOnly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lists := []Wishlist{}
+	for _, w := range res.Wishlists {
+		lists = append(lists, *fromProtoWishlist(w))
+	}
+	return lists, nil
+}
+
+func (c *Client) GetSharedWishlist(ctx context.Context, token string) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.GetSharedWishlist(ctx, &pb.GetSharedWishlistRequest{ShareToken: token}))
+}
+
+func (c *Client) CreateWishlist(ctx context.Context, accountId, name string, public bool) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.CreateWishlist(ctx, &pb.CreateWishlistRequest{
+		AccountId: accountId,
+		Name:      name,
+		Public:    public,
+	}))
+}
+
+func (c *Client) UpdateWishlist(ctx context.Context, id, accountId string, name *string, public *bool) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.UpdateWishlist(ctx, &pb.UpdateWishlistRequest{
+		Id:        id,
+		AccountId: accountId,
+		Name:      name,
+		Public:    public,
+	}))
+}
+
+func (c *Client) DeleteWishlist(ctx context.Context, id, accountId string) error {
+	_, err := c.service.DeleteWishlist(ctx, &pb.DeleteWishlistRequest{
+		Id:        id,
+		AccountId: accountId,
+	})
+	return fromWishlistStatus(err)
+}
+
+// AddToWishlist adds a product to a wishlist of the account, or to its
+// default wishlist when wishlistId is empty
+func (c *Client) AddToWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.AddToWishlist(ctx, &pb.AddToWishlistRequest{
+		AccountId:  accountId,
+		WishlistId: wishlistId,
+		ProductId:  productId,
+	}))
+}
+
+func (c *Client) RemoveFromWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.RemoveFromWishlist(ctx, &pb.RemoveFromWishlistRequest{
+		AccountId:  accountId,
+		WishlistId: wishlistId,
+		ProductId:  productId,
+	}))
+}
+
+// MoveWishlistItem moves a product between wishlists of the account and
+// returns the wishlist it was moved to
+func (c *Client) MoveWishlistItem(ctx context.Context, accountId, productId, fromId, toId string) (*Wishlist, error) {
+	return fromWishlistResponse(c.service.MoveWishlistItem(ctx, &pb.MoveWishlistItemRequest{
+		AccountId:      accountId,
+		ProductId:      productId,
+		FromWishlistId: fromId,
+		ToWishlistId:   toId,
+	}))
+}
+
+func fromWishlistResponse(res *pb.WishlistResponse, err error) (*Wishlist, error) {
+	if err != nil {
+		return nil, fromWishlistStatus(err)
+	}
+	return fromProtoWishlist(res.Wishlist), nil
+}
+
+// fromWishlistStatus maps the status codes of wishlistStatus back to errors
+// callers can check. Failed preconditions keep the server's message.
+func fromWishlistStatus(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	if status.Code(err) == codes.AlreadyExists {
+		return ErrWishlistExists
+	}
+	if status.Code(err) == codes.InvalidArgument {
+		return ErrInvalidWishlistName
+	}
+	return err
+}
+
+func fromProtoWishlist(w *pb.Wishlist) *Wishlist {
+	wishlist := &Wishlist{
+		ID:         w.GetId(),
+		AccountID:  w.GetAccountId(),
+		Name:       w.GetName(),
+		Default:    w.GetIsDefault(),
+		ShareToken: w.GetShareToken(),
+		Items:      []WishlistItem{},
+	}
+	wishlist.CreatedAt.UnmarshalBinary(w.GetCreatedAt())
+	for _, item := range w.GetItems() {
+		i := WishlistItem{ProductID: item.GetProductId()}
+		i.AddedAt.UnmarshalBinary(item.GetAddedAt())
+		wishlist.Items = append(wishlist.Items, i)
+	}
+	return wishlist
+}
+
+func (c *Client) AskQuestion(ctx context.Context, productId, accountId, body string) (*Question, error) {
+	return fromQuestionResponse(c.service.AskQuestion(ctx, &pb.AskQuestionRequest{
+		ProductId: productId,
+		AccountId: accountId,
+		Body:      body,
+	}))
+}
+
+func (c *Client) GetProductQuestions(ctx context.Context, productId string, skip, take uint64) ([]Question, error) {
+	res, err := c.service.GetProductQuestions(ctx, &pb.GetProductQuestionsRequest{
+		ProductId: productId,
+		Skip:      skip,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, fromQuestionStatus(err)
+	}
+
+	questions := []Question{}
+	for _, q := range res.Questions {
+		questions = append(questions, *fromProtoQuestion(q))
+	}
+	return questions, nil
+}
+
+func (c *Client) GetQuestion(ctx context.Context, id string) (*Question, error) {
+	return fromQuestionResponse(c.service.GetQuestion(ctx, &pb.GetQuestionRequest{
+		QuestionId: id,
+	}))
+}
+
+// AnswerQuestion answers as the seller of the product, or as one of its
+// buyers with verifiedPurchase
+func (c *Client) AnswerQuestion(ctx context.Context, questionId, accountId, body string, verifiedPurchase bool) (*Question, error) {
+	return fromQuestionResponse(c.service.AnswerQuestion(ctx, &pb.AnswerQuestionRequest{
+		QuestionId:       questionId,
+		AccountId:        accountId,
+		Body:             body,
+		VerifiedPurchase: verifiedPurchase,
+	}))
+}
+
+func (c *Client) ModerateQuestion(ctx context.Context, questionId, status string) (*Question, error) {
+	return fromQuestionResponse(c.service.ModerateQuestion(ctx, &pb.ModerateQuestionRequest{
+		QuestionId: questionId,
+		Status:     status,
+	}))
+}
+
+func (c *Client) ModerateAnswer(ctx context.Context, answerId, status string) (*Question, error) {
+	return fromQuestionResponse(c.service.ModerateAnswer(ctx, &pb.ModerateAnswerRequest{
+		AnswerId: answerId,
+		Status:   status,
+	}))
+}
+
+func fromQuestionResponse(res *pb.QuestionResponse, err error) (*Question, error) {
+	if err != nil {
+		return nil, fromQuestionStatus(err)
+	}
+	return fromProtoQuestion(res.Question), nil
+}
+
+func fromQuestionStatus(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrNotFound
+	}
+	return err
+}
+
+func fromProtoQuestion(q *pb.Question) *Question {
+	question := &Question{
+		ID:        q.GetId(),
+		ProductID: q.GetProductId(),
+		AccountID: q.GetAccountId(),
+		Body:      q.GetBody(),
+		Status:    q.GetStatus(),
+		Answers:   []Answer{},
+	}
+	question.CreatedAt.UnmarshalBinary(q.GetCreatedAt())
+	for _, a := range q.GetAnswers() {
+		answer := Answer{
+			ID:               a.GetId(),
+			QuestionID:       a.GetQuestionId(),
+			AccountID:        a.GetAccountId(),
+			Body:             a.GetBody(),
+			FromSeller:       a.GetFromSeller(),
+			VerifiedPurchase: a.GetVerifiedPurchase(),
+			Status:           a.GetStatus(),
+		}
+		answer.CreatedAt.UnmarshalBinary(a.GetCreatedAt())
+		question.Answers = append(question.Answers, answer)
+	}
+	return question
+}
+
+// GetProductHistory returns the versions of a product, newest first
+func (c *Client) GetProductHistory(ctx context.Context, productId string, skip, take uint64) ([]ProductVersion, error) {
+	res, err := c.service.GetProductHistory(ctx, &pb.GetProductHistoryRequest{
+		ProductId: productId,
+		Skip:      skip,
+		Take:      take,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []ProductVersion{}
+	for _, v := range res.Versions {
+		version := ProductVersion{
+			Version:   v.Version,
+			ChangedBy: v.ChangedBy,
+			Changes:   []FieldChange{},
+		}
+		version.ChangedAt.UnmarshalBinary(v.ChangedAt)
+		for _, c := range v.Changes {
+			version.Changes = append(version.Changes, FieldChange{Field: c.Field, From: c.From, To: c.To})
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// RevertProduct restores the name, description, category, price and
+// attributes the product had at version
+func (c *Client) RevertProduct(ctx context.Context, productId, accountId, version string) (*Product, error) {
+	res, err := c.service.RevertProduct(ctx, &pb.RevertProductRequest{
+		ProductId: productId,
+		AccountId: accountId,
+		Version:   version,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrNotFound
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		return nil, ErrUnauthorized
+	}
+	if status.Code(err) == codes.Aborted {
+		return nil, ErrVersionConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+func (c *Client) GetScreeningRules(ctx context.Context) (*ScreeningRules, error) {
+	res, err := c.service.GetScreeningRules(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoScreeningRules(res.Rules), nil
+}
+
+func (c *Client) UpdateScreeningRules(ctx context.Context, rules ScreeningRules) (*ScreeningRules, error) {
+	res, err := c.service.UpdateScreeningRules(ctx, &pb.UpdateScreeningRulesRequest{
+		Rules: &pb.ScreeningRules{
+			BannedTerms:      rules.BannedTerms,
+			MinPrice:         toProtoMoney(rules.MinPrice),
+			MaxPrice:         toProtoMoney(rules.MaxPrice),
+			DetectDuplicates: rules.DetectDuplicates,
+		},
+	})
+	if status.Code(err) == codes.InvalidArgument {
+		return nil, ErrInvalidScreeningRules
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fromProtoScreeningRules(res.Rules), nil
+}
+
+func fromProtoScreeningRules(r *pb.ScreeningRules) *ScreeningRules {
+	rules := &ScreeningRules{
+		BannedTerms:      r.GetBannedTerms(),
+		MinPrice:         fromProtoMoney(r.GetMinPrice()),
+		MaxPrice:         fromProtoMoney(r.GetMaxPrice()),
+		DetectDuplicates: r.GetDetectDuplicates(),
+	}
+	if rules.BannedTerms == nil {
+		rules.BannedTerms = []string{}
+	}
+	rules.UpdatedAt.UnmarshalBinary(r.GetUpdatedAt())
+	return rules
+}
+
+// ListFlaggedProducts returns the products waiting for moderation, longest
+// waiting first
+func (c *Client) ListFlaggedProducts(ctx context.Context, skip, take uint64) ([]Product, error) {
+	res, err := c.service.ListFlaggedProducts(ctx, &pb.ListFlaggedProductsRequest{
+		Skip: skip,
+		Take: take,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	products := []Product{}
+	for _, p := range res.Products {
+		products = append(products, *fromProtoProduct(p))
+	}
+	return products, nil
+}
+
+// ApproveProduct lists a flagged product. adminId is recorded as the author
+// of the change.
+func (c *Client) ApproveProduct(ctx context.Context, id, adminId string) (*Product, error) {
+	return fromModeratedProductResponse(c.service.ApproveProduct(ctx, &pb.ApproveProductRequest{
+		ProductId: id,
+		AccountId: adminId,
+	}))
+}
+
+// RejectProduct keeps a flagged product hidden, with note explaining why to
+// its seller
+func (c *Client) RejectProduct(ctx context.Context, id, adminId, note string) (*Product, error) {
+	return fromModeratedProductResponse(c.service.RejectProduct(ctx, &pb.RejectProductRequest{
+		ProductId: id,
+		Note:      note,
+		AccountId: adminId,
+	}))
+}
+
+func fromModeratedProductResponse(res *pb.ProductResponse, err error) (*Product, error) {
+	if err != nil {
+		return nil, fromProductStatus(err)
+	}
+	return fromProtoProduct(res.Product), nil
+}
+
+// fromProductStatus maps a status from productStatus back to the error named
+// by its ErrorInfo. Other errors are returned as they are.
+func fromProductStatus(err error) error {
+	for _, detail := range status.Convert(err).Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != productErrorDomain {
+			continue
+		}
+		for _, e := range productErrors {
+			if e.reason == info.GetReason() {
+				return e.err
+			}
+		}
+	}
+	return err
+}
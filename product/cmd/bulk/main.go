@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	ProductServiceURL string `envconfig:"PRODUCT_SERVICE_URL" default:"localhost:8080"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatalf("failed to process envconfig: %v", err)
+	}
+
+	accountId := flag.String("account", "", "account ID that owns the products")
+	importPath := flag.String("import", "", "file to import products from")
+	exportPath := flag.String("export", "", "file to export products to")
+	format := flag.String("format", "", "csv or jsonl (defaults to the file extension)")
+	flag.Parse()
+
+	if *accountId == "" || (*importPath == "") == (*exportPath == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client, err := product.NewClient(cfg.ProductServiceURL)
+	if err != nil {
+		log.Fatalf("failed to connect to product service: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	if *importPath != "" {
+		f, err := os.Open(*importPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+
+		result, err := client.ImportProducts(ctx, *accountId, formatFor(*format, *importPath), f)
+		if err != nil {
+			log.Fatalf("import failed: %v", err)
+		}
+
+		for _, rowErr := range result.Errors {
+			log.Printf("row %d: %s", rowErr.Row, rowErr.Message)
+		}
+		log.Printf("imported %d products, %d rows failed", result.Imported, result.Failed)
+		if result.Failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	f, err := os.Create(*exportPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := client.ExportProducts(ctx, *accountId, formatFor(*format, *exportPath), f); err != nil {
+		log.Fatalf("export failed: %v", err)
+	}
+	log.Printf("exported products to %s", *exportPath)
+}
+
+func formatFor(format, path string) string {
+	if format != "" {
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return product.FormatJSONL
+	default:
+		return product.FormatCSV
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	DatabaseURL      string `envconfig:"DATABASE_URL" required:"true"`
+	ElasticsearchURL string `envconfig:"ELASTICSEARCH_URL" default:"http://localhost:9200"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatalf("failed to process envconfig: %v", err)
+	}
+
+	seed := flag.Bool("seed", false, "copy products, reviews, price history and settings that only exist in Elasticsearch into Postgres")
+	rebuild := flag.Bool("rebuild", false, "build a new catalog index from Postgres and swap the alias to it")
+	deleteOld := flag.Bool("delete-old", false, "with -rebuild, delete the previous index once the alias has been swapped")
+	check := flag.Bool("check", false, "report products whose catalog document does not match Postgres")
+	repair := flag.Bool("repair", false, "with -check, queue drifted products for projection")
+	flag.Parse()
+
+	if !*seed && !*rebuild && !*check {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	r, err := product.NewPostgresRepository(cfg.DatabaseURL, cfg.ElasticsearchURL)
+	if err != nil {
+		log.Fatalf("failed to create repository: %v", err)
+	}
+	defer r.Close()
+
+	ctx := context.Background()
+
+	if *seed {
+		seeded, err := r.SeedFromCatalog(ctx)
+		if err != nil {
+			log.Fatalf("seed failed: %v", err)
+		}
+		log.Printf("seeded %d products from the catalog index", seeded)
+
+		seeded, err = r.SeedReviewsAndSettings(ctx)
+		if err != nil {
+			log.Fatalf("seed failed: %v", err)
+		}
+		log.Printf("seeded %d reviews, prices, category schemas and settings", seeded)
+	}
+
+	if *rebuild {
+		index, err := r.RebuildCatalog(ctx, *deleteOld)
+		if err != nil {
+			log.Fatalf("rebuild failed: %v", err)
+		}
+		log.Printf("catalog alias now points to %s", index)
+	}
+
+	if *check {
+		drift, err := r.CheckCatalog(ctx, *repair)
+		if err != nil {
+			log.Fatalf("check failed: %v", err)
+		}
+		log.Printf("checked %d products, %d waiting for projection", drift.Checked, drift.Pending)
+		for _, id := range drift.Missing {
+			log.Printf("missing from index: %s", id)
+		}
+		for _, id := range drift.Stale {
+			log.Printf("stale in index: %s", id)
+		}
+		for _, id := range drift.Orphaned {
+			log.Printf("not in Postgres: %s", id)
+		}
+		if drift.Count() > 0 {
+			if *repair {
+				log.Printf("queued %d drifted products for projection", drift.Count())
+				return
+			}
+			log.Fatalf("found %d drifted products", drift.Count())
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/go-systems-lab/go-ecommerce-lld/product"
@@ -9,9 +11,20 @@ import (
 )
 
 type Config struct {
-	ElasticsearchURL      string `envconfig:"ELASTICSEARCH_URL" default:"http://localhost:9200"`
-	Port                  int    `envconfig:"PORT" default:"8080"`
-	KafkaBootstrapServers string `envconfig:"KAFKA_BOOTSTRAP_SERVERS" default:"kafka:9092"`
+	DatabaseURL             string        `envconfig:"DATABASE_URL" required:"true"`
+	ElasticsearchURL        string        `envconfig:"ELASTICSEARCH_URL" default:"http://localhost:9200"`
+	Port                    int           `envconfig:"PORT" default:"8080"`
+	KafkaBootstrapServers   string        `envconfig:"KAFKA_BOOTSTRAP_SERVERS" default:"kafka:9092"`
+	MediaDir                string        `envconfig:"MEDIA_DIR" default:"./media"`
+	MediaPort               int           `envconfig:"MEDIA_PORT" default:"8081"`
+	MediaBaseURL            string        `envconfig:"MEDIA_BASE_URL" default:"http://localhost:8081/media"`
+	SynonymsFile            string        `envconfig:"SYNONYMS_FILE" default:"product/synonyms.txt"`
+	PriceSchedulerInterval  time.Duration `envconfig:"PRICE_SCHEDULER_INTERVAL" default:"1m"`
+	ProjectionInterval      time.Duration `envconfig:"PROJECTION_INTERVAL" default:"5s"`
+	OutboxRelayInterval     time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL" default:"5s"`
+	PopularityFlushInterval time.Duration `envconfig:"POPULARITY_FLUSH_INTERVAL" default:"30s"`
+	ExchangeRatesFile       string        `envconfig:"EXCHANGE_RATES_FILE"`
+	ReviewListings          bool          `envconfig:"REVIEW_LISTINGS" default:"false"`
 }
 
 func main() {
@@ -20,7 +33,7 @@ func main() {
 		log.Fatalf("failed to process envconfig: %v", err)
 	}
 
-	var r product.Repository
+	var r product.PostgresRepository
 	var producer sarama.AsyncProducer
 	if err := envconfig.Process("", &cfg); err != nil {
 		log.Fatal(err)
@@ -33,13 +46,101 @@ func main() {
 
 	defer producer.Close()
 
-	r, err = product.NewElasticRepository(cfg.ElasticsearchURL)
+	// Events recorded with product writes are only removed from the outbox
+	// once every in-sync replica has them
+	relayConfig := sarama.NewConfig()
+	relayConfig.Producer.Return.Successes = true
+	relayConfig.Producer.RequiredAcks = sarama.WaitForAll
+	relayProducer, err := sarama.NewSyncProducer([]string{cfg.KafkaBootstrapServers}, relayConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer relayProducer.Close()
+
+	consumerConfig := sarama.NewConfig()
+	consumerConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+	popularityGroup, err := sarama.NewConsumerGroup([]string{cfg.KafkaBootstrapServers}, "product_popularity", consumerConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer popularityGroup.Close()
+
+	r, err = product.NewPostgresRepository(cfg.DatabaseURL, cfg.ElasticsearchURL)
 	if err != nil {
 		log.Fatalf("failed to create repository: %v", err)
 	}
 	defer r.Close()
 
+	store, err := product.NewFileBlobStore(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		log.Fatalf("failed to create blob store: %v", err)
+	}
+
+	go func() {
+		log.Printf("serving product media on port %d", cfg.MediaPort)
+		log.Fatal(product.ListenMedia(cfg.MediaDir, cfg.MediaPort))
+	}()
+
 	log.Printf("starting product service on port %d", cfg.Port)
-	s := product.NewProductService(r, producer)
+	s := product.NewProductService(r, producer, store, cfg.ReviewListings)
+
+	if err := seedSynonyms(s, cfg.SynonymsFile); err != nil {
+		log.Printf("failed to seed search synonyms: %v", err)
+	}
+	if err := loadExchangeRates(s, cfg.ExchangeRatesFile); err != nil {
+		log.Printf("failed to load exchange rates: %v", err)
+	}
+
+	go product.RunCatalogProjector(context.Background(), r, cfg.ProjectionInterval)
+	go product.RunOutboxRelay(context.Background(), r, relayProducer, cfg.OutboxRelayInterval)
+	go product.RunPopularityConsumer(context.Background(), r, popularityGroup, cfg.PopularityFlushInterval)
+	go product.RunPriceScheduler(context.Background(), s, cfg.PriceSchedulerInterval)
+
 	log.Fatal(product.ListenGRPC(s, cfg.Port))
 }
+
+func seedSynonyms(s product.Service, path string) error {
+	ctx := context.Background()
+
+	rules, err := s.GetSearchSynonyms(ctx)
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 || path == "" {
+		return nil
+	}
+
+	rules, err = product.LoadSynonymsFile(path)
+	if err != nil {
+		return err
+	}
+
+	rules, err = s.UpdateSearchSynonyms(ctx, rules)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("seeded %d search synonym rules from %s", len(rules), path)
+	return nil
+}
+
+// loadExchangeRates replaces the stored rates with the contents of path on
+// every start, so a configured file wins over rates set through the API
+func loadExchangeRates(s product.Service, path string) error {
+	if path == "" {
+		return nil
+	}
+
+	rates, err := product.LoadExchangeRatesFile(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.UpdateExchangeRates(context.Background(), rates)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("loaded exchange rates from %s", path)
+	return nil
+}
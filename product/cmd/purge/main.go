@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	ProductServiceURL string `envconfig:"PRODUCT_SERVICE_URL" default:"localhost:8080"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatalf("failed to process envconfig: %v", err)
+	}
+
+	retention := flag.Duration("retention", product.DefaultArchiveRetention, "purge products archived for longer than this")
+	flag.Parse()
+
+	client, err := product.NewClient(cfg.ProductServiceURL)
+	if err != nil {
+		log.Fatalf("failed to connect to product service: %v", err)
+	}
+	defer client.Close()
+
+	purged, err := client.PurgeArchivedProducts(context.Background(), *retention)
+	if err != nil {
+		log.Fatalf("purge failed: %v", err)
+	}
+	log.Printf("purged %d archived products", purged)
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/product"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	ElasticsearchURL string `envconfig:"ELASTICSEARCH_URL" default:"http://localhost:9200"`
+}
+
+func main() {
+	var cfg Config
+	if err := envconfig.Process("", &cfg); err != nil {
+		log.Fatalf("failed to process envconfig: %v", err)
+	}
+
+	deleteOld := flag.Bool("delete-old", false, "delete the previous index once the alias has been swapped")
+	flag.Parse()
+
+	r, err := product.NewElasticRepository(cfg.ElasticsearchURL)
+	if err != nil {
+		log.Fatalf("failed to create repository: %v", err)
+	}
+	defer r.Close()
+
+	index, err := r.ReindexCatalog(context.Background(), *deleteOld)
+	if err != nil {
+		log.Fatalf("reindex failed: %v", err)
+	}
+	log.Printf("catalog alias now points to %s", index)
+}
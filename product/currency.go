@@ -0,0 +1,138 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+)
+
+const (
+	exchangeRatesIndex = "exchange_rates"
+	exchangeRatesID    = "current"
+)
+
+var (
+	ErrNoExchangeRates = errors.New("exchange rates have not been loaded")
+)
+
+var exchangeRatesMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"base": map[string]interface{}{"type": "keyword"},
+		"rates": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"updatedAt": map[string]interface{}{"type": "date"},
+	},
+}
+
+func (p productService) GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error) {
+	rates, err := p.repo.GetExchangeRates(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrNoExchangeRates
+	}
+	return rates, err
+}
+
+// UpdateExchangeRates replaces the rates used to convert prices between
+// currencies
+func (p productService) UpdateExchangeRates(ctx context.Context, rates money.ExchangeRates) (*money.ExchangeRates, error) {
+	rates, err := rates.Validate()
+	if err != nil {
+		return nil, err
+	}
+	rates.UpdatedAt = time.Now().UTC()
+
+	if err := p.repo.PutExchangeRates(ctx, rates); err != nil {
+		return nil, err
+	}
+
+	log.Printf("UpdateExchangeRates: loaded %d rates against %s", len(rates.Rates), rates.Base)
+	return &rates, nil
+}
+
+// LoadExchangeRatesFile reads rates from a JSON file, see
+// money.ReadExchangeRates
+func LoadExchangeRatesFile(path string) (money.ExchangeRates, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return money.ExchangeRates{}, err
+	}
+	defer f.Close()
+
+	return money.ReadExchangeRates(f)
+}
+
+func (r *elasticRepository) ensureExchangeRatesIndex(ctx context.Context) error {
+	return r.ensureIndex(ctx, exchangeRatesIndex, exchangeRatesMapping)
+}
+
+func (r *elasticRepository) GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error) {
+	res, err := r.client.Get(
+		exchangeRatesIndex,
+		exchangeRatesID,
+		r.client.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get exchange rates: %s", res.String())
+	}
+
+	var result struct {
+		Source money.ExchangeRates `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result.Source, nil
+}
+
+func (r *elasticRepository) PutExchangeRates(ctx context.Context, rates money.ExchangeRates) error {
+	docBytes, err := json.Marshal(rates)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Index(
+		exchangeRatesIndex,
+		bytes.NewReader(docBytes),
+		r.client.Index.WithDocumentID(exchangeRatesID),
+		r.client.Index.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to store exchange rates: %s", res.String())
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error) {
+	var rates money.ExchangeRates
+	if err := r.getSetting(ctx, exchangeRatesSetting, &rates); err != nil {
+		return nil, err
+	}
+	return &rates, nil
+}
+
+func (r *postgresRepository) PutExchangeRates(ctx context.Context, rates money.ExchangeRates) error {
+	return r.putSetting(ctx, exchangeRatesSetting, rates)
+}
@@ -0,0 +1,355 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+const (
+	// cursorKeepAlive is how long the point in time behind a cursor stays open
+	// after each page but the first is read
+	cursorKeepAlive = "5m"
+	// firstPageKeepAlive is how long the point in time opened by a first page
+	// stays open. Most listings are never paged, so it is kept short.
+	firstPageKeepAlive         = "30s"
+	firstPageKeepAliveDuration = 30 * time.Second
+	// maxFirstPagePITs limits the points in time held by first pages at once
+	maxFirstPagePITs = 100
+
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrCursorExpired = errors.New("cursor has expired, start again from the first page")
+)
+
+// ProductPage is one page of a cursor-paginated listing. Cursors[i] resumes
+// the listing after Products[i].
+type ProductPage struct {
+	Products []Product
+	Cursors  []string
+	HasMore  bool
+}
+
+// productCursor is the decoded form of the opaque cursors handed to clients.
+// The point in time keeps every page on the same snapshot of the catalog, and
+// is empty in cursors from a first page read without one. After holds the
+// raw sort values of the last product read so that large tiebreakers survive
+// the round trip.
+type productCursor struct {
+	PIT    string            `json:"p"`
+	After  []json.RawMessage `json:"a"`
+	Search string            `json:"s"`
+}
+
+func (c productCursor) encode() (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(cursor string) (productCursor, error) {
+	var c productCursor
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || len(c.After) == 0 {
+		return productCursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// searchFingerprint identifies the search a cursor was issued for, so a
+// cursor cannot be resumed with different filters
+func searchFingerprint(query string, minRating float64, sort string, attributes []AttributeFilter) string {
+	filters, _ := json.Marshal(attributes)
+	sum := sha256.Sum256([]byte(query + "\x00" + strconv.FormatFloat(minRating, 'g', -1, 64) + "\x00" + sort + "\x00" + string(filters)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// SearchProductsPage is SearchProducts paginated with cursors instead of an
+// offset, so listings are not limited to the first 10,000 results
+func (p productService) SearchProductsPage(ctx context.Context, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error) {
+	if !slices.Contains(searchSorts, sort) {
+		return nil, ErrInvalidSort
+	}
+	if err := validateAttributeFilters(attributes); err != nil {
+		return nil, err
+	}
+	if take == 0 {
+		take = defaultPageSize
+	}
+	if take > maxPageSize {
+		take = maxPageSize
+	}
+	page, err := p.repo.SearchProductsPage(ctx, query, minRating, sort, attributes, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+	page.Products, err = p.deriveBundleStock(ctx, page.Products)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// SearchProductsPage reads a page of search results with search_after on a
+// point in time, so every page comes from the same snapshot of the catalog.
+// The point in time is opened by the first page with a short keep-alive,
+// which later pages extend, and is closed once the last page has been read.
+func (r *elasticRepository) SearchProductsPage(ctx context.Context, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error) {
+	return r.searchProductsPage(ctx, catalogAlias, query, minRating, sort, attributes, take, cursor)
+}
+
+func (r *elasticRepository) searchProductsPage(ctx context.Context, index, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error) {
+	search := searchFingerprint(query, minRating, sort, attributes)
+
+	var pitID string
+	var after []json.RawMessage
+	keepAlive := cursorKeepAlive
+	release := func() {}
+	if cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Search != search {
+			return nil, ErrInvalidCursor
+		}
+		pitID, after = c.PIT, c.After
+		if pitID == "" {
+			// The first page was read without a point in time, see
+			// firstPagePITs. Products whose sort values changed since then
+			// can be skipped or repeated at this page boundary.
+			if pitID, err = r.openPointInTime(ctx, index, cursorKeepAlive); err != nil {
+				return nil, err
+			}
+			after = resumeAfter(after)
+		}
+	} else if slot, ok := r.firstPagePIT(); ok {
+		var err error
+		if pitID, err = r.openPointInTime(ctx, index, firstPageKeepAlive); err != nil {
+			slot()
+			return nil, err
+		}
+		keepAlive = firstPageKeepAlive
+		release = slot
+	}
+
+	page, err := r.readProductsPage(ctx, index, pageQuery(query, minRating, sort, attributes, take, pitID, keepAlive, after), take, search, pitID)
+	if err != nil {
+		release()
+		if errors.Is(err, errPITNotFound) {
+			if cursor == "" {
+				return nil, err
+			}
+			return nil, ErrCursorExpired
+		}
+		return nil, err
+	}
+	if !page.HasMore && pitID != "" {
+		r.closePointInTime(ctx, pitID)
+		release()
+	} else {
+		// The slot is held until the first page's keep-alive runs out, so
+		// abandoned listings cannot hold more than maxFirstPagePITs open
+		time.AfterFunc(firstPageKeepAliveDuration, release)
+	}
+	return page, nil
+}
+
+var errPITNotFound = errors.New("point in time not found")
+
+// firstPagePIT takes one of the slots that limit how many first pages hold
+// a point in time at once. When none is free the first page is read from the
+// live catalog instead.
+func (r *elasticRepository) firstPagePIT() (release func(), ok bool) {
+	select {
+	case r.firstPagePITs <- struct{}{}:
+		var once sync.Once
+		return func() {
+			once.Do(func() { <-r.firstPagePITs })
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+// resumeAfter extends the sort values of a page read without a point in time
+// with a _shard_doc value. IDs are unique, so only the hit the cursor points
+// at can share the other values, and it must sort before the search_after
+// point to be left out.
+func resumeAfter(after []json.RawMessage) []json.RawMessage {
+	return append(slices.Clone(after), json.RawMessage(strconv.FormatInt(math.MaxInt64, 10)))
+}
+
+// pageQuery builds the search of a page of SearchProductsPage. Results are
+// sorted by ID after the requested sort, and by _shard_doc when read from a
+// point in time.
+func pageQuery(query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, pitID, keepAlive string, after []json.RawMessage) map[string]interface{} {
+	searchQuery := productSearch(query, minRating, sort, attributes)
+	sorts, ok := searchQuery["sort"].([]interface{})
+	if !ok {
+		sorts = []interface{}{"_score"}
+	}
+	sorts = append(sorts, map[string]interface{}{"id": "asc"})
+	if pitID != "" {
+		sorts = append(sorts, map[string]interface{}{"_shard_doc": "asc"})
+	}
+	searchQuery["sort"] = sorts
+	searchQuery["size"] = take + 1
+	searchQuery["seq_no_primary_term"] = true
+	searchQuery["version"] = true
+	searchQuery["track_total_hits"] = false
+	if pitID != "" {
+		searchQuery["pit"] = map[string]interface{}{
+			"id":         pitID,
+			"keep_alive": keepAlive,
+		}
+	}
+	if after != nil {
+		searchQuery["search_after"] = after
+	}
+	return searchQuery
+}
+
+// readProductsPage runs searchQuery, against index unless it reads a point
+// in time, and returns up to take products with their cursors
+func (r *elasticRepository) readProductsPage(ctx context.Context, index string, searchQuery map[string]interface{}, take uint64, search, pitID string) (*ProductPage, error) {
+	queryBytes, err := json.Marshal(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []func(*esapi.SearchRequest){
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithContext(ctx),
+	}
+	if pitID == "" {
+		opts = append(opts, r.client.Search.WithIndex(index))
+	}
+
+	res, err := r.client.Search(opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 && pitID != "" {
+		return nil, errPITNotFound
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to search products: %s", res.String())
+	}
+
+	var result struct {
+		PitID string `json:"pit_id"`
+		Hits  struct {
+			Hits []json.RawMessage `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if result.PitID != "" {
+		pitID = result.PitID
+	}
+
+	hits := result.Hits.Hits
+	page := &ProductPage{HasMore: uint64(len(hits)) > take}
+	if page.HasMore {
+		hits = hits[:take]
+	}
+
+	for _, raw := range hits {
+		var hitMap map[string]interface{}
+		if err := json.Unmarshal(raw, &hitMap); err != nil {
+			return nil, err
+		}
+		var hit struct {
+			ID     string            `json:"_id"`
+			Source ProductDocument   `json:"_source"`
+			Sort   []json.RawMessage `json:"sort"`
+		}
+		if err := json.Unmarshal(raw, &hit); err != nil {
+			return nil, err
+		}
+
+		next, err := productCursor{PIT: pitID, After: hit.Sort, Search: search}.encode()
+		if err != nil {
+			return nil, err
+		}
+
+		p := hit.Source.toProduct(hit.ID)
+		p.Version = r.hitVersion(hitMap)
+		page.Products = append(page.Products, p)
+		page.Cursors = append(page.Cursors, next)
+	}
+	return page, nil
+}
+
+func (r *elasticRepository) openPointInTime(ctx context.Context, index, keepAlive string) (string, error) {
+	res, err := r.client.OpenPointInTime(
+		[]string{index},
+		keepAlive,
+		r.client.OpenPointInTime.WithContext(ctx),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", fmt.Errorf("failed to open point in time: %s", res.String())
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// closePointInTime releases a point in time early. Failures are only logged
+// since the point in time expires on its own.
+func (r *elasticRepository) closePointInTime(ctx context.Context, pitID string) {
+	body, err := json.Marshal(map[string]interface{}{"id": pitID})
+	if err != nil {
+		return
+	}
+
+	res, err := r.client.ClosePointInTime(
+		r.client.ClosePointInTime.WithBody(bytes.NewReader(body)),
+		r.client.ClosePointInTime.WithContext(ctx),
+	)
+	if err != nil {
+		log.Printf("Error closing point in time: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		log.Printf("Error closing point in time: %s", res.String())
+	}
+}
@@ -0,0 +1,221 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+)
+
+func TestProductCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor productCursor
+	}{
+		{
+			name:   "first page without point in time",
+			cursor: productCursor{After: []json.RawMessage{json.RawMessage("4.5"), json.RawMessage(`"iphone-15"`)}, Search: "0123456789abcdef"},
+		},
+		{
+			name:   "point in time with large tiebreaker",
+			cursor: productCursor{PIT: "pit-id", After: []json.RawMessage{json.RawMessage(`"iphone-15"`), json.RawMessage("9223372036854775807")}, Search: "0123456789abcdef"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.cursor.encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.cursor) {
+				t.Errorf("decodeCursor() = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "not json", cursor: "bm90IGpzb24"},
+		{name: "no sort values", cursor: "eyJwIjoicGl0LWlkIiwicyI6ImFiYyJ9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("decodeCursor(%q) error = %v, want %v", tt.cursor, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestSearchFingerprint(t *testing.T) {
+	color := []AttributeFilter{{Name: "color", Values: []string{"red"}}}
+	base := searchFingerprint("phone", 4, SortRating, color)
+
+	if again := searchFingerprint("phone", 4, SortRating, []AttributeFilter{{Name: "color", Values: []string{"red"}}}); again != base {
+		t.Errorf("searchFingerprint() = %s for the same search, want %s", again, base)
+	}
+
+	tests := []struct {
+		name        string
+		fingerprint string
+	}{
+		{name: "query", fingerprint: searchFingerprint("phones", 4, SortRating, color)},
+		{name: "min rating", fingerprint: searchFingerprint("phone", 4.5, SortRating, color)},
+		{name: "sort", fingerprint: searchFingerprint("phone", 4, SortBestSelling, color)},
+		{name: "attributes", fingerprint: searchFingerprint("phone", 4, SortRating, []AttributeFilter{{Name: "color", Values: []string{"blue"}}})},
+		{name: "no attributes", fingerprint: searchFingerprint("phone", 4, SortRating, nil)},
+		{name: "separator in query", fingerprint: searchFingerprint("phone\x004", 0, SortRating, color)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.fingerprint == base {
+				t.Errorf("searchFingerprint() = %s, want a different fingerprint when the %s changes", tt.fingerprint, tt.name)
+			}
+		})
+	}
+}
+
+func TestResumeAfter(t *testing.T) {
+	after := []json.RawMessage{json.RawMessage("0"), json.RawMessage(`"iphone-15"`)}
+
+	got := resumeAfter(after)
+	want := []json.RawMessage{json.RawMessage("0"), json.RawMessage(`"iphone-15"`), json.RawMessage("9223372036854775807")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resumeAfter() = %s, want %s", got, want)
+	}
+	if len(after) != 2 {
+		t.Errorf("resumeAfter() changed the cursor's sort values to %s", after)
+	}
+}
+
+// TestSearchProductsPageBoundary walks a listing whose products all share the
+// same rating two at a time, so every page boundary falls between tied
+// products. It needs an Elasticsearch cluster and is skipped unless
+// ELASTICSEARCH_TEST_URL is set.
+func TestSearchProductsPageBoundary(t *testing.T) {
+	url := os.Getenv("ELASTICSEARCH_TEST_URL")
+	if url == "" {
+		t.Skip("ELASTICSEARCH_TEST_URL is not set")
+	}
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{url},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	suffix := time.Now().UnixNano()
+	index := fmt.Sprintf("catalog-test-%d", suffix)
+	synonymSet := fmt.Sprintf("catalog-synonyms-test-%d", suffix)
+
+	putTestSynonyms(t, client, synonymSet, []string{"couch, sofa"})
+	t.Cleanup(func() {
+		res, err := client.SynonymsDeleteSynonym(synonymSet)
+		if err == nil {
+			res.Body.Close()
+		}
+	})
+
+	createTestCatalog(t, client, index, synonymSet)
+	t.Cleanup(func() {
+		res, err := client.Indices.Delete([]string{index})
+		if err == nil {
+			res.Body.Close()
+		}
+	})
+
+	indexFixtures(t, client, index, "testdata/catalog.json")
+	want := []string{"cotton-tshirt", "galaxy-s24", "iphone-15", "sofa-3-seat", "zip-hoodie"}
+
+	tests := []struct {
+		name          string
+		firstPagePITs chan struct{}
+		// snapshot is set when products added after the first page must not
+		// be listed
+		snapshot bool
+	}{
+		{name: "first page on a point in time", firstPagePITs: make(chan struct{}, 1), snapshot: true},
+		{name: "first page on the live catalog", firstPagePITs: nil},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &elasticRepository{client: client, firstPagePITs: tt.firstPagePITs}
+			ctx := context.Background()
+
+			var ids []string
+			var cursor string
+			for page := 0; ; page++ {
+				if page > len(want) {
+					t.Fatalf("listing did not end after %d pages: %q", page, ids)
+				}
+				got, err := repo.searchProductsPage(ctx, index, "", 0, SortRating, nil, 2, cursor)
+				if err != nil {
+					t.Fatalf("page %d: %v", page+1, err)
+				}
+				for _, p := range got.Products {
+					ids = append(ids, p.ID)
+				}
+				if !got.HasMore {
+					break
+				}
+				cursor = got.Cursors[len(got.Cursors)-1]
+
+				if page == 0 {
+					// Sorts after the first page, before iphone-15
+					indexNewProduct(t, client, index, fmt.Sprintf("hoodie-%d", i))
+				}
+			}
+
+			if tt.snapshot && !reflect.DeepEqual(ids, want) {
+				t.Errorf("listed %q, want %q", ids, want)
+			}
+			seen := map[string]bool{}
+			for _, id := range ids {
+				if seen[id] {
+					t.Errorf("listed %s twice: %q", id, ids)
+				}
+				seen[id] = true
+			}
+			for _, id := range want {
+				if !slices.Contains(ids, id) {
+					t.Errorf("listed %q, missing %s", ids, id)
+				}
+			}
+		})
+	}
+}
+
+func indexNewProduct(t *testing.T, client *elasticsearch.Client, index, id string) {
+	t.Helper()
+
+	data, err := json.Marshal([]Product{{ID: id, Name: "New Product", Category: "clothing", Status: StatusPublished}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "product.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	indexFixtures(t, client, index, path)
+}
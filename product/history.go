@@ -0,0 +1,204 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrNoHistory = errors.New("product history is only kept by the Postgres repository")
+)
+
+// ProductVersion is a stored revision of a product: who changed it, when,
+// and which fields of its document changed since the previous version
+type ProductVersion struct {
+	Version   string
+	ChangedBy string
+	ChangedAt time.Time
+	Changes   []FieldChange
+}
+
+// FieldChange is a document field that changed between two versions. From
+// and To are JSON values, and are empty when the field was not set.
+type FieldChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// HistoryRepository reads the versions recorded with every product write
+type HistoryRepository interface {
+	// ListProductVersions returns the versions of a product, newest first
+	ListProductVersions(ctx context.Context, productId string, skip, take uint64) ([]ProductVersion, error)
+	// GetProductVersion returns the product as it was stored at version
+	GetProductVersion(ctx context.Context, productId, version string) (*Product, error)
+}
+
+type actorKey struct{}
+
+// WithActor returns a context whose product writes are recorded in the
+// product history as made by accountId
+func WithActor(ctx context.Context, accountId string) context.Context {
+	return context.WithValue(ctx, actorKey{}, accountId)
+}
+
+func actorFromContext(ctx context.Context) string {
+	accountId, _ := ctx.Value(actorKey{}).(string)
+	return accountId
+}
+
+func (p productService) historyRepository() (HistoryRepository, error) {
+	if p.history == nil {
+		return nil, ErrNoHistory
+	}
+	return p.history, nil
+}
+
+func (p productService) GetProductHistory(ctx context.Context, productId string, skip, take uint64) ([]ProductVersion, error) {
+	repo, err := p.historyRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.repo.GetProductById(ctx, productId); err != nil {
+		return nil, err
+	}
+	return repo.ListProductVersions(ctx, productId, skip, take)
+}
+
+// RevertProduct restores the fields UpdateProduct changes to their values at
+// version. The revert is stored as a new version, so it can be reverted too.
+// Images, stock and publication are left as they are.
+func (p productService) RevertProduct(ctx context.Context, productId, accountId, version string) (*Product, error) {
+	repo, err := p.historyRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	old, err := repo.GetProductVersion(ctx, productId, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.UpdateProduct(ctx, productId, old.Name, old.Description, old.Category, old.RegularPrice, old.Attributes, accountId, product.Version, updatableFields)
+}
+
+// recordVersion copies the product as just written in tx into its history
+func recordVersion(ctx context.Context, tx pgx.Tx, productId string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO product_versions (product_id, version, document, changed_by)
+		SELECT id, version, document, $2 FROM products WHERE id = $1
+	`, productId, actorFromContext(ctx))
+	return err
+}
+
+func (r *postgresRepository) ListProductVersions(ctx context.Context, productId string, skip, take uint64) ([]ProductVersion, error) {
+	// The previous document is looked up before paging, so the oldest version
+	// of a page is still compared with the one before it
+	rows, err := r.db.Query(ctx, `
+		SELECT version, changed_by, changed_at, document, previous
+		FROM (
+			SELECT version, changed_by, changed_at, document,
+				LAG(document) OVER (ORDER BY version) AS previous
+			FROM product_versions
+			WHERE product_id = $1
+		) AS versions
+		ORDER BY version DESC
+		OFFSET $2 LIMIT $3
+	`, productId, skip, take)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (ProductVersion, error) {
+		var version int64
+		var v ProductVersion
+		var doc, previous []byte
+		if err := row.Scan(&version, &v.ChangedBy, &v.ChangedAt, &doc, &previous); err != nil {
+			return ProductVersion{}, err
+		}
+
+		changes, err := diffDocuments(previous, doc)
+		if err != nil {
+			return ProductVersion{}, err
+		}
+		v.Version = formatProductVersion(version)
+		v.Changes = changes
+		return v, nil
+	})
+}
+
+func (r *postgresRepository) GetProductVersion(ctx context.Context, productId, version string) (*Product, error) {
+	v, err := parseProductVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRow(ctx, `
+		SELECT product_id, document, version FROM product_versions
+		WHERE product_id = $1 AND version = $2
+	`, productId, v)
+
+	p, err := scanProduct(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// diffDocuments lists the top-level fields that differ between two stored
+// documents, by name. Popularity, ratings and stock change without a new
+// version and are left out. A nil before lists every field of after.
+func diffDocuments(before, after []byte) ([]FieldChange, error) {
+	from := map[string]json.RawMessage{}
+	if before != nil {
+		if err := json.Unmarshal(before, &from); err != nil {
+			return nil, err
+		}
+	}
+	to := map[string]json.RawMessage{}
+	if err := json.Unmarshal(after, &to); err != nil {
+		return nil, err
+	}
+
+	fields := map[string]bool{}
+	for field := range from {
+		fields[field] = true
+	}
+	for field := range to {
+		fields[field] = true
+	}
+	delete(fields, "popularity")
+	delete(fields, "rating")
+	delete(fields, "reviewCount")
+	delete(fields, "stock")
+
+	changes := []FieldChange{}
+	for field := range fields {
+		if bytes.Equal(from[field], to[field]) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: field, From: string(from[field]), To: string(to[field])})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes, nil
+}
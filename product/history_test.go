@@ -0,0 +1,76 @@
+package product
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []FieldChange
+	}{
+		{
+			name:  "first version lists every field",
+			after: `{"name":"Zip Hoodie","price":{"amount":4999,"currency":"USD"}}`,
+			want:  []FieldChange{{Field: "name", To: `"Zip Hoodie"`}, {Field: "price", To: `{"amount":4999,"currency":"USD"}`}},
+		},
+		{
+			name:   "changed fields in name order",
+			before: `{"price":{"amount":4999,"currency":"USD"},"name":"Zip Hoodie","category":"clothing"}`,
+			after:  `{"price":{"amount":3999,"currency":"USD"},"name":"Fleece Zip Hoodie","category":"clothing"}`,
+			want: []FieldChange{
+				{Field: "name", From: `"Zip Hoodie"`, To: `"Fleece Zip Hoodie"`},
+				{Field: "price", From: `{"amount":4999,"currency":"USD"}`, To: `{"amount":3999,"currency":"USD"}`},
+			},
+		},
+		{
+			name:   "added and removed fields",
+			before: `{"name":"Zip Hoodie","publishAt":"2026-01-01T00:00:00Z"}`,
+			after:  `{"name":"Zip Hoodie","archivedAt":"2026-02-01T00:00:00Z"}`,
+			want: []FieldChange{
+				{Field: "archivedAt", To: `"2026-02-01T00:00:00Z"`},
+				{Field: "publishAt", From: `"2026-01-01T00:00:00Z"`},
+			},
+		},
+		{
+			name:   "aggregates are left out",
+			before: `{"name":"Zip Hoodie","popularity":{"views":3},"rating":4.5,"reviewCount":2,"stock":40}`,
+			after:  `{"name":"Zip Hoodie","popularity":{"views":9},"rating":4,"reviewCount":3,"stock":38}`,
+			want:   []FieldChange{},
+		},
+		{
+			name:   "no changes",
+			before: `{"name":"Zip Hoodie"}`,
+			after:  `{"name":"Zip Hoodie"}`,
+			want:   []FieldChange{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before []byte
+			if tt.before != "" {
+				before = []byte(tt.before)
+			}
+			got, err := diffDocuments(before, []byte(tt.after))
+			if err != nil {
+				t.Fatalf("diffDocuments() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffDocuments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffDocumentsInvalid(t *testing.T) {
+	if _, err := diffDocuments([]byte(`{"name":`), []byte(`{}`)); err == nil {
+		t.Error("diffDocuments() accepted an invalid document before")
+	}
+	if _, err := diffDocuments(nil, []byte(`[]`)); err == nil {
+		t.Error("diffDocuments() accepted a document that is not an object")
+	}
+}
@@ -0,0 +1,547 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+)
+
+// Products are read and written through the catalog alias, which points at
+// exactly one versioned index (catalog_<timestamp>). Mapping changes that
+// cannot be applied in place are rolled out by ReindexCatalog.
+const catalogAlias = "catalog"
+
+var catalogMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"id": map[string]interface{}{
+			"type": "keyword",
+		},
+		"name": map[string]interface{}{
+			"type": "text",
+			"fields": map[string]interface{}{
+				"keyword": map[string]interface{}{
+					"type":         "keyword",
+					"ignore_above": 256,
+				},
+				"suggest": map[string]interface{}{
+					"type": "search_as_you_type",
+				},
+				"stemmed": stemmedField,
+			},
+		},
+		"description": map[string]interface{}{
+			"type": "text",
+			"fields": map[string]interface{}{
+				"stemmed": stemmedField,
+			},
+		},
+		"category": map[string]interface{}{
+			"type": "keyword",
+			"fields": map[string]interface{}{
+				"suggest": map[string]interface{}{
+					"type": "search_as_you_type",
+				},
+			},
+		},
+		"currency": map[string]interface{}{
+			"type": "keyword",
+		},
+		"priceAmount": map[string]interface{}{
+			"type": "long",
+		},
+		"accountId": map[string]interface{}{
+			"type": "keyword",
+		},
+		"images": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"archivedAt": map[string]interface{}{
+			"type": "date",
+		},
+		"rating": map[string]interface{}{
+			"type": "float",
+		},
+		"reviewCount": map[string]interface{}{
+			"type": "integer",
+		},
+		"regularPriceAmount": map[string]interface{}{
+			"type": "long",
+		},
+		"priceSchedules": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"nextPriceChangeAt": map[string]interface{}{
+			"type": "date",
+		},
+		"attributes": attributesMapping,
+		"status": map[string]interface{}{
+			"type": "keyword",
+		},
+		"publishAt": map[string]interface{}{
+			"type": "date",
+		},
+		"popularity": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"views":      map[string]interface{}{"type": "long"},
+				"sales":      map[string]interface{}{"type": "long"},
+				"viewScore":  map[string]interface{}{"type": "double"},
+				"salesScore": map[string]interface{}{"type": "double"},
+				"updatedAt":  map[string]interface{}{"type": "date"},
+			},
+		},
+		"stock": map[string]interface{}{
+			"type": "long",
+		},
+		"bundle": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"productId": map[string]interface{}{"type": "keyword"},
+				"quantity":  map[string]interface{}{"type": "integer"},
+			},
+		},
+		"screening": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status":     map[string]interface{}{"type": "keyword"},
+				"reasons":    map[string]interface{}{"type": "keyword", "index": false},
+				"flaggedAt":  map[string]interface{}{"type": "date"},
+				"note":       map[string]interface{}{"type": "text", "index": false},
+				"reviewedAt": map[string]interface{}{"type": "date"},
+			},
+		},
+	},
+}
+
+// migrateDocumentScript converts decimal prices in documents written before
+// prices were stored in minor units, and copies the _id of documents written
+// before it was repeated in the document. It is applied whenever documents
+// are copied, so reindexing the catalog migrates existing products.
+const migrateDocumentScript = `
+def s = ctx._source;
+if (s.id == null) {
+	s.id = ctx._id;
+}
+if (s.currency == null) {
+	s.currency = params.currency;
+	double price = s.price == null ? 0 : s.remove('price');
+	double regularPrice = s.regularPrice == null ? 0 : s.remove('regularPrice');
+	s.priceAmount = Math.round(price * params.scale);
+	if (regularPrice == 0 && (s.priceSchedules == null || s.priceSchedules.isEmpty())) {
+		s.regularPriceAmount = s.priceAmount;
+	} else {
+		s.regularPriceAmount = Math.round(regularPrice * params.scale);
+	}
+	if (s.priceSchedules != null) {
+		for (def schedule : s.priceSchedules) {
+			double schedulePrice = schedule.price == null ? 0 : schedule.remove('price');
+			schedule.priceAmount = Math.round(schedulePrice * params.scale);
+		}
+	}
+}`
+
+func newCatalogIndexName() string {
+	return fmt.Sprintf("%s_%s", catalogAlias, time.Now().UTC().Format("20060102150405"))
+}
+
+// ensureCatalogIndex makes sure the catalog alias points at a managed index.
+// A legacy concrete catalog index is migrated into a versioned index, and
+// additive mapping changes are applied to the current index in place.
+func (r *elasticRepository) ensureCatalogIndex(ctx context.Context) error {
+	if err := r.ensureSynonymSet(ctx); err != nil {
+		return err
+	}
+
+	current, err := r.catalogIndices(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(current) == 0:
+		index := newCatalogIndexName()
+		log.Printf("Creating catalog index %s", index)
+		if err := r.createCatalogIndex(ctx, index); err != nil {
+			return err
+		}
+		return r.updateAliases(ctx, map[string]interface{}{
+			"add": map[string]interface{}{"index": index, "alias": catalogAlias, "is_write_index": true},
+		})
+	case len(current) == 1 && current[0] == catalogAlias:
+		log.Printf("Migrating unmanaged %s index to a versioned index", catalogAlias)
+		_, err := r.ReindexCatalog(ctx, true)
+		return err
+	default:
+		if err := r.putMapping(ctx, catalogAlias, catalogMapping); err != nil {
+			log.Printf("Catalog mapping is out of date, run the reindex command: %v", err)
+		}
+		return nil
+	}
+}
+
+// ReindexCatalog copies the catalog into a new index built from the current
+// settings and mapping, then atomically moves the alias to it. Documents are
+// copied a second time after the swap so writes that reached the old index
+// during the first pass are not lost.
+func (r *elasticRepository) ReindexCatalog(ctx context.Context, deleteOld bool) (string, error) {
+	current, err := r.catalogIndices(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(current) != 1 {
+		return "", fmt.Errorf("expected %s to resolve to one index, found %v", catalogAlias, current)
+	}
+	old := current[0]
+	legacy := old == catalogAlias
+
+	index := newCatalogIndexName()
+	log.Printf("Reindexing %s into %s", old, index)
+
+	if err := r.createCatalogIndex(ctx, index); err != nil {
+		return "", err
+	}
+
+	if err := r.copyDocuments(ctx, old, index); err != nil {
+		return "", err
+	}
+
+	if legacy {
+		// A concrete index cannot share its name with an alias, so the legacy
+		// index is removed in the same atomic step that creates the alias
+		err = r.updateAliases(ctx,
+			map[string]interface{}{
+				"add": map[string]interface{}{"index": index, "alias": catalogAlias, "is_write_index": true},
+			},
+			map[string]interface{}{
+				"remove_index": map[string]interface{}{"index": old},
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+
+		log.Printf("Catalog alias now points to %s", index)
+		return index, nil
+	}
+
+	// Products deleted from the old index while it was copied are still in
+	// the new one. Remember what was copied so they can be found after the
+	// catch-up copy.
+	copied := map[string]scrollHit{}
+	err = r.scrollIndex(ctx, index, map[string]interface{}{
+		"query":               map[string]interface{}{"match_all": map[string]interface{}{}},
+		"_source":             false,
+		"seq_no_primary_term": true,
+	}, func(hit scrollHit) error {
+		copied[hit.ID] = hit
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = r.updateAliases(ctx,
+		map[string]interface{}{
+			"remove": map[string]interface{}{"index": old, "alias": catalogAlias},
+		},
+		map[string]interface{}{
+			"add": map[string]interface{}{"index": index, "alias": catalogAlias, "is_write_index": true},
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Catalog alias now points to %s", index)
+
+	if err := r.copyDocuments(ctx, old, index); err != nil {
+		return "", err
+	}
+	if err := r.applyDeletes(ctx, old, index, copied); err != nil {
+		return "", err
+	}
+
+	if deleteOld {
+		res, err := r.client.Indices.Delete([]string{old}, r.client.Indices.Delete.WithContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		defer res.Body.Close()
+
+		if res.IsError() {
+			return "", fmt.Errorf("failed to delete index %s: %s", old, res.String())
+		}
+		log.Printf("Deleted index %s", old)
+	}
+
+	return index, nil
+}
+
+// catalogIndices returns the indices behind the catalog alias, or the
+// catalog index itself if it predates alias management
+func (r *elasticRepository) catalogIndices(ctx context.Context) ([]string, error) {
+	res, err := r.client.Indices.Get([]string{catalogAlias}, r.client.Indices.Get.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to resolve %s: %s", catalogAlias, res.String())
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	var indices []string
+	for index := range result {
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+// ensureIndex creates a plain index with mapping, or applies additive mapping
+// changes if it already exists
+func (r *elasticRepository) ensureIndex(ctx context.Context, index string, mapping map[string]interface{}) error {
+	res, err := r.client.Indices.Exists([]string{index}, r.client.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode == 200 {
+		return r.putMapping(ctx, index, mapping)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"mappings": mapping})
+	if err != nil {
+		return err
+	}
+
+	res, err = r.client.Indices.Create(
+		index,
+		r.client.Indices.Create.WithBody(bytes.NewReader(body)),
+		r.client.Indices.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to create index %s: %s", index, res.String())
+	}
+	return nil
+}
+
+func (r *elasticRepository) createCatalogIndex(ctx context.Context, index string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"settings": map[string]interface{}{"analysis": catalogAnalysis},
+		"mappings": catalogMapping,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.Create(
+		index,
+		r.client.Indices.Create.WithBody(bytes.NewReader(body)),
+		r.client.Indices.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to create index %s: %s", index, res.String())
+	}
+	return nil
+}
+
+func (r *elasticRepository) putMapping(ctx context.Context, index string, mapping map[string]interface{}) error {
+	body, err := json.Marshal(mapping)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.PutMapping(
+		[]string{index},
+		bytes.NewReader(body),
+		r.client.Indices.PutMapping.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to update %s mapping: %s", index, res.String())
+	}
+	return nil
+}
+
+// copyDocuments reindexes source into dest keeping source document versions,
+// so documents already newer in dest are skipped rather than overwritten
+func (r *elasticRepository) copyDocuments(ctx context.Context, source, dest string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"conflicts": "proceed",
+		"source": map[string]interface{}{
+			"index": source,
+		},
+		"dest": map[string]interface{}{
+			"index":        dest,
+			"version_type": "external",
+		},
+		"script": map[string]interface{}{
+			"lang":   "painless",
+			"source": migrateDocumentScript,
+			"params": map[string]interface{}{
+				"currency": money.DefaultCurrency,
+				"scale":    money.Scale(money.DefaultCurrency),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Reindex(
+		bytes.NewReader(body),
+		r.client.Reindex.WithWaitForCompletion(true),
+		r.client.Reindex.WithRefresh(true),
+		r.client.Reindex.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to reindex %s into %s: %s", source, dest, res.String())
+	}
+
+	var result struct {
+		Total            int               `json:"total"`
+		Created          int               `json:"created"`
+		Updated          int               `json:"updated"`
+		VersionConflicts int               `json:"version_conflicts"`
+		Failures         []json.RawMessage `json:"failures"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("reindex of %s into %s had %d failures, first: %s", source, dest, len(result.Failures), result.Failures[0])
+	}
+
+	log.Printf("Copied %s into %s: %d total, %d created, %d updated, %d skipped",
+		source, dest, result.Total, result.Created, result.Updated, result.VersionConflicts)
+	return nil
+}
+
+// applyDeletes removes from dest the copied documents that no longer exist
+// in source. A document written to dest since it was copied is kept, as it
+// was recreated after the alias moved.
+func (r *elasticRepository) applyDeletes(ctx context.Context, source, dest string, copied map[string]scrollHit) error {
+	err := r.scrollIndex(ctx, source, map[string]interface{}{
+		"query":   map[string]interface{}{"match_all": map[string]interface{}{}},
+		"_source": false,
+	}, func(hit scrollHit) error {
+		delete(copied, hit.ID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(copied) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for id, hit := range copied {
+		action := map[string]interface{}{
+			"delete": map[string]interface{}{
+				"_index":          dest,
+				"_id":             id,
+				"if_seq_no":       hit.SeqNo,
+				"if_primary_term": hit.PrimaryTerm,
+			},
+		}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+	}
+
+	res, err := r.client.Bulk(
+		&body,
+		r.client.Bulk.WithRefresh("true"),
+		r.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to apply deletes to %s: %s", dest, res.String())
+	}
+
+	var result struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	for _, item := range result.Items {
+		for _, op := range item {
+			// 409: rewritten since it was copied, 404: already deleted
+			if op.Error != nil && op.Status != 409 && op.Status != 404 {
+				return fmt.Errorf("failed to apply deletes to %s: %s: %s", dest, op.Error.Type, op.Error.Reason)
+			}
+		}
+	}
+
+	log.Printf("Removed up to %d products deleted from %s during the copy", len(copied), source)
+	return nil
+}
+
+func (r *elasticRepository) updateAliases(ctx context.Context, actions ...map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Indices.UpdateAliases(
+		bytes.NewReader(body),
+		r.client.Indices.UpdateAliases.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to update %s alias: %s", catalogAlias, res.String())
+	}
+
+	_, err = io.Copy(io.Discard, res.Body)
+	return err
+}
@@ -0,0 +1,276 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxImageSize = 10 << 20
+
+	// Images are decoded whole before thumbnails are built, so their
+	// dimensions are checked first to bound the memory a decode takes
+	maxImageEdge   = 10000
+	maxImagePixels = 40_000_000
+)
+
+var (
+	ErrImageTooLarge     = errors.New("image exceeds maximum upload size")
+	ErrImageDimensions   = errors.New("image must be at most 10000 pixels per side and 40 megapixels")
+	ErrUnsupportedImage  = errors.New("unsupported image format")
+	ErrImageNotFound     = errors.New("image not found")
+	ErrInvalidImageOrder = errors.New("image order must list every image exactly once")
+)
+
+type Thumbnail struct {
+	Size   string `json:"size"`
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type ProductImage struct {
+	ID         string      `json:"id"`
+	Key        string      `json:"key"`
+	URL        string      `json:"url"`
+	Width      int         `json:"width"`
+	Height     int         `json:"height"`
+	Position   int         `json:"position"`
+	Thumbnails []Thumbnail `json:"thumbnails"`
+}
+
+var thumbnailSizes = []struct {
+	Name    string
+	MaxEdge int
+}{
+	{"small", 150},
+	{"medium", 400},
+	{"large", 800},
+}
+
+func (p productService) AddProductImage(ctx context.Context, productId, accountId string, r io.Reader) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if err := checkImageDimensions(config.Width, config.Height); err != nil {
+		return nil, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	imageId := uuid.New().String()
+	prefix := fmt.Sprintf("products/%s/%s", productId, imageId)
+	key := fmt.Sprintf("%s/original.%s", prefix, format)
+
+	if err := p.store.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	productImage := ProductImage{
+		ID:       imageId,
+		Key:      key,
+		URL:      p.store.URL(key),
+		Width:    bounds.Dx(),
+		Height:   bounds.Dy(),
+		Position: len(product.Images),
+	}
+
+	for _, size := range thumbnailSizes {
+		thumb := resizeToFit(img, size.MaxEdge)
+
+		var buf bytes.Buffer
+		thumbKey := fmt.Sprintf("%s/%s.jpg", prefix, size.Name)
+		if format == "png" {
+			thumbKey = fmt.Sprintf("%s/%s.png", prefix, size.Name)
+			err = png.Encode(&buf, thumb)
+		} else {
+			err = jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: 85})
+		}
+		if err != nil {
+			p.store.DeletePrefix(ctx, prefix)
+			return nil, err
+		}
+
+		if err := p.store.Put(ctx, thumbKey, &buf); err != nil {
+			p.store.DeletePrefix(ctx, prefix)
+			return nil, err
+		}
+
+		productImage.Thumbnails = append(productImage.Thumbnails, Thumbnail{
+			Size:   size.Name,
+			Key:    thumbKey,
+			URL:    p.store.URL(thumbKey),
+			Width:  thumb.Bounds().Dx(),
+			Height: thumb.Bounds().Dy(),
+		})
+	}
+
+	product.Images = append(product.Images, productImage)
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		p.store.DeletePrefix(ctx, prefix)
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (p productService) RemoveProductImage(ctx context.Context, productId, imageId, accountId string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	var images []ProductImage
+	for _, img := range product.Images {
+		if img.ID != imageId {
+			img.Position = len(images)
+			images = append(images, img)
+		}
+	}
+	if len(images) == len(product.Images) {
+		return nil, ErrImageNotFound
+	}
+
+	product.Images = images
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.store.DeletePrefix(ctx, fmt.Sprintf("products/%s/%s", productId, imageId)); err != nil {
+		log.Printf("Error deleting image blobs for product %s: %v", productId, err)
+	}
+
+	return product, nil
+}
+
+func (p productService) ReorderProductImages(ctx context.Context, productId, accountId string, imageIds []string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	if len(imageIds) != len(product.Images) {
+		return nil, ErrInvalidImageOrder
+	}
+
+	positions := make(map[string]int, len(imageIds))
+	for i, id := range imageIds {
+		if _, dup := positions[id]; dup {
+			return nil, ErrInvalidImageOrder
+		}
+		positions[id] = i
+	}
+
+	for i, img := range product.Images {
+		position, ok := positions[img.ID]
+		if !ok {
+			return nil, ErrInvalidImageOrder
+		}
+		product.Images[i].Position = position
+	}
+
+	sort.Slice(product.Images, func(i, j int) bool {
+		return product.Images[i].Position < product.Images[j].Position
+	})
+
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// checkImageDimensions bounds the pixels a decode of the image allocates
+func checkImageDimensions(width, height int) error {
+	if width > maxImageEdge || height > maxImageEdge || width*height > maxImagePixels {
+		return ErrImageDimensions
+	}
+	return nil
+}
+
+// resizeToFit scales img down with a box filter so that neither edge exceeds maxEdge
+func resizeToFit(img image.Image, maxEdge int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return img
+	}
+
+	dw, dh := maxEdge, h*maxEdge/w
+	if h > w {
+		dw, dh = w*maxEdge/h, maxEdge
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := bounds.Min.Y + y*h/dh
+		y1 := max(bounds.Min.Y+(y+1)*h/dh, y0+1)
+		for x := 0; x < dw; x++ {
+			x0 := bounds.Min.X + x*w/dw
+			x1 := max(bounds.Min.X+(x+1)*w/dw, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,62 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+)
+
+func TestCheckImageDimensions(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		want   error
+	}{
+		{name: "small", width: 800, height: 600},
+		{name: "longest edge", width: maxImageEdge, height: 1},
+		{name: "most pixels", width: maxImageEdge, height: maxImagePixels / maxImageEdge},
+		{name: "edge too long", width: 1, height: maxImageEdge + 1, want: ErrImageDimensions},
+		{name: "too many pixels", width: 6400, height: 6400, want: ErrImageDimensions},
+		{name: "both too large", width: maxImageEdge + 1, height: maxImageEdge + 1, want: ErrImageDimensions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkImageDimensions(tt.width, tt.height); err != tt.want {
+				t.Errorf("checkImageDimensions(%d, %d) = %v, want %v", tt.width, tt.height, err, tt.want)
+			}
+		})
+	}
+}
+
+// imageRepository serves a single product. Every other method panics.
+type imageRepository struct {
+	Repository
+	product Product
+}
+
+func (r *imageRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
+	if id != r.product.ID {
+		return nil, ErrNotFound
+	}
+	p := r.product
+	return &p, nil
+}
+
+func TestAddProductImageTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, maxImageEdge+1, 1))); err != nil {
+		t.Fatal(err)
+	}
+
+	// A nil store fails the test if the image gets as far as being stored
+	service := productService{repo: &imageRepository{product: Product{ID: "poster", AccountID: "seller"}}}
+	_, err := service.AddProductImage(context.Background(), "poster", "seller", &buf)
+	if !errors.Is(err, ErrImageDimensions) {
+		t.Errorf("AddProductImage() error = %v, want %v", err, ErrImageDimensions)
+	}
+}
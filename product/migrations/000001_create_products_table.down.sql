@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS catalog_projection_queue;
+DROP TABLE IF EXISTS products;
@@ -0,0 +1,27 @@
+-- Products are stored as their catalog document. Columns used for lookups
+-- and scans are kept alongside it.
+CREATE TABLE IF NOT EXISTS products (
+    id TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL,
+    document JSONB NOT NULL,
+    version BIGINT NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE,
+    next_price_change_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_account_id ON products(account_id);
+CREATE INDEX IF NOT EXISTS idx_products_archived_at ON products(archived_at) WHERE archived_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_products_next_price_change_at ON products(next_price_change_at) WHERE next_price_change_at IS NOT NULL;
+
+-- Products whose current state still has to be written to the catalog
+-- search index. Rows are added in the same transaction as the product write.
+CREATE TABLE IF NOT EXISTS catalog_projection_queue (
+    product_id TEXT PRIMARY KEY,
+    enqueued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_catalog_projection_queue_enqueued_at ON catalog_projection_queue(enqueued_at);
@@ -0,0 +1,16 @@
+-- Events recorded in the same transaction as the product write they
+-- describe. The product service relays them to Kafka and deletes them once
+-- the broker has acknowledged them.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic TEXT NOT NULL,
+    key TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_key ON event_outbox(key, id);
+CREATE INDEX IF NOT EXISTS idx_event_outbox_next_attempt_at ON event_outbox(next_attempt_at);
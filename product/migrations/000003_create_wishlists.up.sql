@@ -0,0 +1,25 @@
+-- Named product lists of an account. Each account has at most one default
+-- list, and a list is public while it has a share token. Names are unique
+-- among the lists an account creates.
+CREATE TABLE IF NOT EXISTS wishlists (
+    id TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    share_token TEXT UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wishlists_account_id_name ON wishlists(account_id, lower(name)) WHERE NOT is_default;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wishlists_default ON wishlists(account_id) WHERE is_default;
+
+-- Items leave every list when their product is purged
+CREATE TABLE IF NOT EXISTS wishlist_items (
+    wishlist_id TEXT NOT NULL REFERENCES wishlists(id) ON DELETE CASCADE,
+    product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (wishlist_id, product_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlist_items_product_id ON wishlist_items(product_id);
@@ -0,0 +1,26 @@
+-- Questions shoppers ask about a product and the answers of its seller and
+-- buyers. Both are listed while approved and hidden once rejected by a
+-- moderator.
+CREATE TABLE IF NOT EXISTS product_questions (
+    id TEXT PRIMARY KEY,
+    product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    account_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'approved',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_questions_product_id ON product_questions(product_id, created_at DESC) WHERE status = 'approved';
+
+CREATE TABLE IF NOT EXISTS product_answers (
+    id TEXT PRIMARY KEY,
+    question_id TEXT NOT NULL REFERENCES product_questions(id) ON DELETE CASCADE,
+    account_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    from_seller BOOLEAN NOT NULL DEFAULT FALSE,
+    verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'approved',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_answers_question_id ON product_answers(question_id, created_at);
@@ -0,0 +1,18 @@
+-- Every stored revision of a product document, written in the transaction
+-- that bumps the product version. changed_by is the account that made the
+-- change, the admin for moderation decisions, or empty for changes made by
+-- the service itself, such as scheduled prices. Ratings, popularity and
+-- stock change without a new version.
+CREATE TABLE IF NOT EXISTS product_versions (
+    product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    version BIGINT NOT NULL,
+    document JSONB NOT NULL,
+    changed_by TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (product_id, version)
+);
+
+-- Existing products start their history at their current version
+INSERT INTO product_versions (product_id, version, document, changed_at)
+SELECT id, version, document, updated_at FROM products
+ON CONFLICT DO NOTHING;
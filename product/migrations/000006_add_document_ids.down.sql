@@ -0,0 +1,3 @@
+UPDATE product_versions SET document = document - 'id';
+
+UPDATE products SET document = document - 'id';
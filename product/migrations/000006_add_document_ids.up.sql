@@ -0,0 +1,11 @@
+-- Documents repeat their product ID so catalog listings can sort on it
+UPDATE products SET document = document || jsonb_build_object('id', id)
+WHERE NOT document ? 'id';
+
+UPDATE product_versions SET document = document || jsonb_build_object('id', product_id)
+WHERE NOT document ? 'id';
+
+-- Project every product again so catalog documents get the ID too
+INSERT INTO catalog_projection_queue (product_id)
+SELECT id FROM products
+ON CONFLICT (product_id) DO NOTHING;
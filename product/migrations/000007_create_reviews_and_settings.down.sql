@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS catalog_settings;
+DROP TABLE IF EXISTS category_schemas;
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS product_reviews;
@@ -0,0 +1,50 @@
+-- Reviews, price history and catalog settings were only kept in
+-- Elasticsearch. The catalog command's -seed copies them from the indices of
+-- existing deployments.
+
+-- Reviews are stored under reviewID, so each account reviews a product once.
+-- version is bumped by every update, which only succeeds at the version the
+-- review was read at.
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id TEXT PRIMARY KEY,
+    product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    account_id TEXT NOT NULL,
+    rating SMALLINT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    verified_purchase BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'approved',
+    reply JSONB,
+    helpful_voters TEXT[] NOT NULL DEFAULT '{}',
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_reviews_product_id ON product_reviews(product_id, cardinality(helpful_voters) DESC, created_at DESC) WHERE status = 'approved';
+
+-- Every price a product has had. Amounts are in minor units of currency.
+CREATE TABLE IF NOT EXISTS price_history (
+    id BIGSERIAL PRIMARY KEY,
+    product_id TEXT NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    currency TEXT NOT NULL,
+    price_amount BIGINT NOT NULL,
+    compare_at_price_amount BIGINT NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_product_id ON price_history(product_id, changed_at DESC);
+
+CREATE TABLE IF NOT EXISTS category_schemas (
+    category TEXT PRIMARY KEY,
+    schema JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Settings of the whole catalog, such as exchange rates, screening rules and
+-- search synonyms, stored as JSON under a fixed key
+CREATE TABLE IF NOT EXISTS catalog_settings (
+    key TEXT PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
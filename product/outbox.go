@@ -0,0 +1,243 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"log"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	outboxBatchSize = 100
+
+	// outboxMaxBackoff caps the delay before a failed event is retried
+	outboxMaxBackoff = 5 * time.Minute
+)
+
+var (
+	ErrNoOutbox = errors.New("events can only be recorded by the Postgres repository")
+)
+
+// Relay metrics, served with the other expvars at /debug/vars
+var (
+	outboxPending    = expvar.NewInt("outbox_pending")
+	outboxLagSeconds = expvar.NewFloat("outbox_lag_seconds")
+	outboxRelayed    = expvar.NewInt("outbox_relayed_total")
+	outboxFailures   = expvar.NewInt("outbox_failures_total")
+)
+
+// OutboxEvent is an event recorded with a product write and waiting to be
+// relayed to Kafka
+type OutboxEvent struct {
+	ID        int64
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+	Attempts  int
+}
+
+// OutboxStats describes the events waiting to be relayed. Lag is the age of
+// the oldest one.
+type OutboxStats struct {
+	Pending int64
+	Lag     time.Duration
+}
+
+// recordEvents adds events to the outbox in the transaction of the product
+// write they describe. Events are keyed by product so that a product's
+// events land on one partition in order.
+func recordEvents(ctx context.Context, tx pgx.Tx, events ...Event) error {
+	for _, event := range events {
+		var key string
+		if event.Data.ID != nil {
+			key = *event.Data.ID
+		}
+
+		if err := recordMessage(ctx, tx, productEventsTopic, key, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordMessage adds a message for topic to the outbox in tx. Messages with
+// the same key are relayed in the order they were recorded.
+func recordMessage(ctx context.Context, tx pgx.Tx, topic, key string, message interface{}) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO event_outbox (topic, key, payload)
+		VALUES ($1, $2, $3)
+	`, topic, key, payload)
+	return err
+}
+
+// RelayEvents hands up to limit recorded events, oldest first, to send and
+// deletes the ones it accepted. An event is only handed out once every
+// earlier event for the same product has been relayed, so consumers see a
+// product's events in order. Events send rejects are retried with
+// exponential backoff.
+func (r *postgresRepository) RelayEvents(ctx context.Context, limit int, send func([]OutboxEvent) []error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT o.id, o.topic, o.key, o.payload, o.created_at, o.attempts
+		FROM event_outbox o
+		WHERE o.next_attempt_at <= NOW()
+			AND NOT EXISTS (
+				SELECT 1 FROM event_outbox e WHERE e.key = o.key AND e.id < o.id
+			)
+		ORDER BY o.id
+		LIMIT $1
+		FOR UPDATE OF o SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (OutboxEvent, error) {
+		var e OutboxEvent
+		err := row.Scan(&e.ID, &e.Topic, &e.Key, &e.Payload, &e.CreatedAt, &e.Attempts)
+		return e, err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	sendErrors := send(events)
+
+	var relayed []int64
+	for i, e := range events {
+		if sendErrors[i] == nil {
+			relayed = append(relayed, e.ID)
+			continue
+		}
+
+		log.Printf("Error relaying event %d to %s: %v", e.ID, e.Topic, sendErrors[i])
+		_, err := tx.Exec(ctx, `
+			UPDATE event_outbox SET
+				attempts = attempts + 1,
+				last_error = $2,
+				next_attempt_at = NOW() + $3 * INTERVAL '1 second'
+			WHERE id = $1
+		`, e.ID, sendErrors[i].Error(), outboxBackoff(e.Attempts+1).Seconds())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM event_outbox WHERE id = ANY($1)`, relayed); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	outboxRelayed.Add(int64(len(relayed)))
+	outboxFailures.Add(int64(len(events) - len(relayed)))
+	return len(relayed), nil
+}
+
+func (r *postgresRepository) OutboxStats(ctx context.Context) (*OutboxStats, error) {
+	var stats OutboxStats
+	var oldest *time.Time
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*), MIN(created_at) FROM event_outbox`).Scan(&stats.Pending, &oldest)
+	if err != nil {
+		return nil, err
+	}
+	if oldest != nil {
+		stats.Lag = time.Since(*oldest)
+	}
+	return &stats, nil
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := time.Second
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
+
+// RunOutboxRelay relays recorded events to Kafka after every write, and
+// every interval to retry failures, until ctx is done. An event is only
+// removed from the outbox once the broker has acknowledged it, so events are
+// delivered at least once and consumers must tolerate duplicates.
+func RunOutboxRelay(ctx context.Context, r PostgresRepository, producer sarama.SyncProducer, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	send := func(events []OutboxEvent) []error {
+		return sendEvents(producer, events)
+	}
+
+	for {
+		for {
+			relayed, err := r.RelayEvents(ctx, outboxBatchSize, send)
+			if err != nil {
+				log.Printf("Error relaying events: %v", err)
+				break
+			}
+			if relayed < outboxBatchSize {
+				break
+			}
+		}
+
+		if stats, err := r.OutboxStats(ctx); err != nil {
+			log.Printf("Error reading outbox stats: %v", err)
+		} else {
+			outboxPending.Set(stats.Pending)
+			outboxLagSeconds.Set(stats.Lag.Seconds())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.EventsPending():
+		}
+	}
+}
+
+// sendEvents publishes events in one batch and returns the error of each
+func sendEvents(producer sarama.SyncProducer, events []OutboxEvent) []error {
+	messages := make([]*sarama.ProducerMessage, len(events))
+	for i, e := range events {
+		messages[i] = &sarama.ProducerMessage{
+			Topic:    e.Topic,
+			Key:      sarama.StringEncoder(e.Key),
+			Value:    sarama.ByteEncoder(e.Payload),
+			Metadata: i,
+		}
+	}
+
+	sendErrors := make([]error, len(events))
+	err := producer.SendMessages(messages)
+
+	var producerErrors sarama.ProducerErrors
+	if errors.As(err, &producerErrors) {
+		for _, pe := range producerErrors {
+			sendErrors[pe.Msg.Metadata.(int)] = pe.Err
+		}
+	} else if err != nil {
+		for i := range sendErrors {
+			sendErrors[i] = err
+		}
+	}
+	return sendErrors
+}
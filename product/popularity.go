@@ -0,0 +1,253 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// InteractionEventsTopic carries product views from this service and
+	// purchases from the order service
+	InteractionEventsTopic = "interaction_events"
+
+	// popularityHalfLife is how long it takes for a view or sale to count
+	// half as much towards popularity
+	popularityHalfLife = 7 * 24 * time.Hour
+
+	// salesWeight is how many views a sale is worth when boosting search
+	// results, matching the weight the recommender gives purchases
+	salesWeight = 3
+
+	popularityBatchSize = 500
+)
+
+// popularityEpoch is the reference time of popularity scores, see
+// Popularity. Scores grow by a factor of two every half-life after it and
+// stay within float64 range for about 1000 half-lives.
+var popularityEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Popularity counts how often a product has been viewed and sold. The
+// scores are forward-decayed: every view or sale adds
+// 2^((t-popularityEpoch)/popularityHalfLife), so recent interactions weigh
+// exponentially more and scores can be compared without rewriting every
+// document as time passes. Multiplying a score by decayFactor(now) gives
+// the decayed count at now.
+type Popularity struct {
+	Views      int64     `json:"views"`
+	Sales      int64     `json:"sales"`
+	ViewScore  float64   `json:"viewScore"`
+	SalesScore float64   `json:"salesScore"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// PopularityCount is the number of views and sales of a product seen since
+// the last flush
+type PopularityCount struct {
+	Views int64
+	Sales int64
+}
+
+// popularityWeight is the score a single interaction at t adds
+func popularityWeight(t time.Time) float64 {
+	return math.Exp2(float64(t.Sub(popularityEpoch)) / float64(popularityHalfLife))
+}
+
+// decayFactor turns scores into decayed counts at now
+func decayFactor(now time.Time) float64 {
+	return 1 / popularityWeight(now)
+}
+
+// popularityBoost multiplies the relevance of search results by one plus the
+// log of their decayed popularity, so that popular products rank higher
+// among similarly relevant ones. The time is rounded to the hour to keep
+// scores stable across the pages of a cursor.
+func popularityBoost(query map[string]interface{}, now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"function_score": map[string]interface{}{
+			"query": query,
+			"script_score": map[string]interface{}{
+				"script": map[string]interface{}{
+					"source": popularityBoostScript,
+					"params": map[string]interface{}{
+						"decay":       decayFactor(now.Truncate(time.Hour)),
+						"salesWeight": salesWeight,
+					},
+				},
+			},
+			"boost_mode": "multiply",
+		},
+	}
+}
+
+const popularityBoostScript = `
+double views = doc['popularity.viewScore'].size() == 0 ? 0 : doc['popularity.viewScore'].value;
+double sales = doc['popularity.salesScore'].size() == 0 ? 0 : doc['popularity.salesScore'].value;
+return 1 + Math.log1p((views + params.salesWeight * sales) * params.decay);
+`
+
+// bestSellingSort orders products by decayed sales, then by lifetime sales
+func bestSellingSort() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"popularity.salesScore": map[string]interface{}{"order": "desc", "missing": "_last"}},
+		map[string]interface{}{"popularity.sales": map[string]interface{}{"order": "desc", "missing": "_last"}},
+		"_score",
+	}
+}
+
+// RecordPopularity adds counts to the popularity of each product. The
+// product version is left alone, so counting does not conflict with edits,
+// and products that no longer exist are ignored.
+func (r *postgresRepository) RecordPopularity(ctx context.Context, counts map[string]PopularityCount, now time.Time) error {
+	ids := make([]string, 0, len(counts))
+	views := make([]int64, 0, len(counts))
+	sales := make([]int64, 0, len(counts))
+	for id, count := range counts {
+		ids = append(ids, id)
+		views = append(views, count.Views)
+		sales = append(sales, count.Sales)
+	}
+
+	return r.write(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			UPDATE products SET document = jsonb_set(products.document, '{popularity}', jsonb_build_object(
+				'views', COALESCE((products.document #>> '{popularity,views}')::bigint, 0) + c.views,
+				'sales', COALESCE((products.document #>> '{popularity,sales}')::bigint, 0) + c.sales,
+				'viewScore', COALESCE((products.document #>> '{popularity,viewScore}')::float8, 0) + c.views * $4::float8,
+				'salesScore', COALESCE((products.document #>> '{popularity,salesScore}')::float8, 0) + c.sales * $4::float8,
+				'updatedAt', $5::timestamptz
+			))
+			FROM unnest($1::text[], $2::bigint[], $3::bigint[]) AS c(id, views, sales)
+			WHERE products.id = c.id
+			RETURNING products.id
+		`, ids, views, sales, popularityWeight(now), now)
+		if err != nil {
+			return err
+		}
+		updated, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+		if len(updated) == 0 {
+			return nil
+		}
+		return enqueueProjection(ctx, tx, updated...)
+	})
+}
+
+// interactionEvent is the part of a view or purchase event that popularity
+// needs. Purchases without a quantity count as one sale.
+type interactionEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		ProductID string `json:"product_id"`
+		Quantity  int64  `json:"quantity"`
+	} `json:"data"`
+}
+
+// count adds views and purchases to counts and ignores other events
+func (e interactionEvent) count(counts map[string]PopularityCount) {
+	if e.Data.ProductID == "" {
+		return
+	}
+
+	c := counts[e.Data.ProductID]
+	switch e.Type {
+	case "product_retrieved", "view":
+		c.Views++
+	case "purchase":
+		c.Sales += max(e.Data.Quantity, 1)
+	default:
+		return
+	}
+	counts[e.Data.ProductID] = c
+}
+
+// popularityConsumer counts the interaction events of a claim in memory and
+// flushes them every flushInterval. Offsets are only marked once the counts
+// they cover are stored, so counts survive restarts, though events read
+// again after a crash are counted twice.
+type popularityConsumer struct {
+	repo          PostgresRepository
+	flushInterval time.Duration
+}
+
+func (c *popularityConsumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *popularityConsumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *popularityConsumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	counts := map[string]PopularityCount{}
+	var last *sarama.ConsumerMessage
+	flush := func() {
+		if last == nil {
+			return
+		}
+		if len(counts) > 0 {
+			if err := c.repo.RecordPopularity(session.Context(), counts, time.Now().UTC()); err != nil {
+				// Keep the counts and retry on the next flush
+				log.Printf("Error recording product popularity: %v", err)
+				return
+			}
+		}
+		session.MarkMessage(last, "")
+		counts = map[string]PopularityCount{}
+		last = nil
+	}
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				flush()
+				return nil
+			}
+
+			var event interactionEvent
+			if err := json.Unmarshal(msg.Value, &event); err != nil {
+				log.Printf("Skipping malformed interaction event at offset %d: %v", msg.Offset, err)
+			} else {
+				event.count(counts)
+			}
+			last = msg
+
+			if len(counts) >= popularityBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// RunPopularityConsumer counts product views and purchases from
+// InteractionEventsTopic into product popularity until ctx is done
+func RunPopularityConsumer(ctx context.Context, r PostgresRepository, group sarama.ConsumerGroup, flushInterval time.Duration) {
+	handler := &popularityConsumer{repo: r, flushInterval: flushInterval}
+	for {
+		if err := group.Consume(ctx, []string{InteractionEventsTopic}, handler); err != nil {
+			log.Printf("Error consuming interaction events: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(flushInterval):
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,536 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresRepository keeps products in Postgres and projects them into the
+// catalog index, which serves search and listings. Reads by ID and scans go
+// to Postgres, as do reviews, price history and catalog settings; searches
+// are inherited from the Elasticsearch repository.
+type PostgresRepository interface {
+	Repository
+	WishlistRepository
+	QuestionRepository
+	HistoryRepository
+	StockRepository
+	// ProjectCatalog writes up to limit queued products to the catalog index
+	// and returns how many were written
+	ProjectCatalog(ctx context.Context, limit int) (int, error)
+	// ProjectionPending is signalled after product writes
+	ProjectionPending() <-chan struct{}
+	RebuildCatalog(ctx context.Context, deleteOld bool) (string, error)
+	CheckCatalog(ctx context.Context, repair bool) (*CatalogDrift, error)
+	SeedFromCatalog(ctx context.Context) (int, error)
+	SeedReviewsAndSettings(ctx context.Context) (int, error)
+	// RelayEvents hands up to limit recorded events to send and returns how
+	// many it accepted
+	RelayEvents(ctx context.Context, limit int, send func([]OutboxEvent) []error) (int, error)
+	// EventsPending is signalled after product writes
+	EventsPending() <-chan struct{}
+	OutboxStats(ctx context.Context) (*OutboxStats, error)
+	RecordPopularity(ctx context.Context, counts map[string]PopularityCount, now time.Time) error
+}
+
+type postgresRepository struct {
+	*elasticRepository
+	db      *pgxpool.Pool
+	pending chan struct{}
+	events  chan struct{}
+}
+
+func NewPostgresRepository(databaseURL, elasticsearchURL string) (PostgresRepository, error) {
+	db, err := pgxpool.New(context.Background(), databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	search, err := newElasticRepository(elasticsearchURL)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	search.externalVersions = true
+
+	return &postgresRepository{
+		elasticRepository: search,
+		db:                db,
+		pending:           make(chan struct{}, 1),
+		events:            make(chan struct{}, 1),
+	}, nil
+}
+
+func (r *postgresRepository) Close() {
+	r.db.Close()
+}
+
+func (r *postgresRepository) ProjectionPending() <-chan struct{} {
+	return r.pending
+}
+
+func (r *postgresRepository) EventsPending() <-chan struct{} {
+	return r.events
+}
+
+// write runs fn in a transaction and wakes the projector and the event relay
+// once it commits
+func (r *postgresRepository) write(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	for _, pending := range []chan struct{}{r.pending, r.events} {
+		select {
+		case pending <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// keepAggregates is merged into the document written over a stored product
+// so that popularity counts, ratings and stock recorded since the product was
+// read are not overwritten. A document without stock stops tracking it.
+func keepAggregates(document string) string {
+	return `jsonb_strip_nulls(jsonb_build_object(
+		'popularity', products.document->'popularity',
+		'rating', products.document->'rating',
+		'reviewCount', products.document->'reviewCount',
+		'stock', CASE WHEN ` + document + ` ? 'stock' THEN products.document->'stock' END
+	))`
+}
+
+// Keys of catalog_settings
+const (
+	exchangeRatesSetting  = "exchange_rates"
+	screeningRulesSetting = "screening_rules"
+	synonymsSetting       = "search_synonyms"
+)
+
+// getSetting decodes the catalog setting stored under key into v, or returns
+// ErrNotFound if it has never been set
+func (r *postgresRepository) getSetting(ctx context.Context, key string, v interface{}) error {
+	err := r.db.QueryRow(ctx, `SELECT value FROM catalog_settings WHERE key = $1`, key).Scan(v)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (r *postgresRepository) putSetting(ctx context.Context, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO catalog_settings (key, value)
+		VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			value = EXCLUDED.value,
+			updated_at = NOW()
+	`, key, value)
+	return err
+}
+
+func enqueueProjection(ctx context.Context, tx pgx.Tx, productIds ...string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO catalog_projection_queue (product_id)
+		SELECT unnest($1::text[])
+		ON CONFLICT (product_id) DO UPDATE SET
+			enqueued_at = NOW(),
+			attempts = 0,
+			last_error = NULL
+	`, productIds)
+	return err
+}
+
+// PutProduct stores the product and records events in the same transaction.
+// Every write that changes the product version also adds the new version to
+// its history.
+func (r *postgresRepository) PutProduct(ctx context.Context, p Product, events ...Event) (string, error) {
+	var version int64
+	err := r.write(ctx, func(tx pgx.Tx) error {
+		var err error
+		if version, err = upsertProduct(ctx, tx, p); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, events...)
+	})
+	if err != nil {
+		return "", err
+	}
+	return formatProductVersion(version), nil
+}
+
+// upsertProduct inserts the product or replaces the stored one. A stored
+// product is never moved to another account: writing it for a different
+// account fails with ErrUnauthorized.
+func upsertProduct(ctx context.Context, tx pgx.Tx, p Product) (int64, error) {
+	doc, err := json.Marshal(newProductDocument(p))
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO products (id, account_id, document, version, archived_at, next_price_change_at)
+		VALUES ($1, $2, $3, 1, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			document = EXCLUDED.document || `+keepAggregates(`EXCLUDED.document`)+`,
+			version = products.version + 1,
+			archived_at = EXCLUDED.archived_at,
+			next_price_change_at = EXCLUDED.next_price_change_at,
+			updated_at = NOW()
+		WHERE products.account_id = EXCLUDED.account_id
+		RETURNING version
+	`, p.ID, p.AccountID, doc, p.ArchivedAt, p.NextPriceChangeAt).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The ID belongs to a product of another account
+		return 0, ErrUnauthorized
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := recordVersion(ctx, tx, p.ID); err != nil {
+		return 0, err
+	}
+	return version, enqueueProjection(ctx, tx, p.ID)
+}
+
+// UpdateProduct replaces the stored product and records events in the same
+// transaction. When updatedProduct carries a version the write only succeeds
+// if the product has not changed since that version was read.
+func (r *postgresRepository) UpdateProduct(ctx context.Context, updatedProduct Product, events ...Event) (string, error) {
+	var expected int64
+	if updatedProduct.Version != "" {
+		var err error
+		expected, err = parseProductVersion(updatedProduct.Version)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	doc, err := json.Marshal(newProductDocument(updatedProduct))
+	if err != nil {
+		return "", err
+	}
+
+	var version int64
+	err = r.write(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			UPDATE products SET
+				account_id = $2,
+				document = $3::jsonb || `+keepAggregates(`$3::jsonb`)+`,
+				version = version + 1,
+				archived_at = $4,
+				next_price_change_at = $5,
+				updated_at = NOW()
+			WHERE id = $1 AND ($6::bigint = 0 OR version = $6)
+			RETURNING version
+		`, updatedProduct.ID, updatedProduct.AccountID, doc, updatedProduct.ArchivedAt, updatedProduct.NextPriceChangeAt, expected).Scan(&version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.missingOrConflict(ctx, tx, updatedProduct.ID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := recordVersion(ctx, tx, updatedProduct.ID); err != nil {
+			return err
+		}
+		if err := enqueueProjection(ctx, tx, updatedProduct.ID); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, events...)
+	})
+	if err != nil {
+		return "", err
+	}
+	return formatProductVersion(version), nil
+}
+
+// missingOrConflict explains why a conditional update matched no rows
+func (r *postgresRepository) missingOrConflict(ctx context.Context, tx pgx.Tx, id string) error {
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
+
+// BulkPutProducts stores each product in its own transaction, along with
+// events[i] if set, so that one failure does not reject the rest of the
+// batch
+func (r *postgresRepository) BulkPutProducts(ctx context.Context, products []Product, events []Event) ([]error, error) {
+	itemErrors := make([]error, len(products))
+	for i, p := range products {
+		err := r.write(ctx, func(tx pgx.Tx) error {
+			if _, err := upsertProduct(ctx, tx, p); err != nil {
+				return err
+			}
+			if i < len(events) {
+				return recordEvents(ctx, tx, events[i])
+			}
+			return nil
+		})
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		itemErrors[i] = err
+	}
+	return itemErrors, nil
+}
+
+func (r *postgresRepository) DeleteProduct(ctx context.Context, productId string) error {
+	return r.write(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM products WHERE id = $1`, productId); err != nil {
+			return err
+		}
+		return enqueueProjection(ctx, tx, productId)
+	})
+}
+
+func (r *postgresRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
+	row := r.db.QueryRow(ctx, `SELECT id, document, version FROM products WHERE id = $1`, id)
+
+	p, err := scanProduct(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ListProductsWithIds returns the products that exist in the order of ids
+func (r *postgresRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]Product, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, document, version FROM products WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := make(map[string]Product, len(ids))
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		found[p.ID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var products []Product
+	for _, id := range ids {
+		if p, ok := found[id]; ok {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (r *postgresRepository) ScanProductsByAccount(ctx context.Context, accountId string, fn func(Product) error) error {
+	return r.scanProducts(ctx, fn, `account_id = $1 AND archived_at IS NULL`, accountId)
+}
+
+func (r *postgresRepository) ScanArchivedProducts(ctx context.Context, before time.Time, fn func(Product) error) error {
+	return r.scanProducts(ctx, fn, `archived_at < $1`, before)
+}
+
+func (r *postgresRepository) ScanPriceChangesDue(ctx context.Context, now time.Time, fn func(Product) error) error {
+	return r.scanProducts(ctx, fn, `next_price_change_at <= $1`, now)
+}
+
+// RefreshProductRating stores the rating aggregated from approved reviews on
+// the product. Like popularity, the rating changes without a new product
+// version, so reviews do not conflict with edits and are not kept in the
+// product history.
+func (r *postgresRepository) RefreshProductRating(ctx context.Context, productId string) error {
+	rating, count, err := r.productRating(ctx, productId)
+	if err != nil {
+		return err
+	}
+
+	return r.write(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE products SET
+				document = document || jsonb_build_object('rating', $2::float8, 'reviewCount', $3::int)
+			WHERE id = $1
+		`, productId, rating, count)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		return enqueueProjection(ctx, tx, productId)
+	})
+}
+
+// AdjustStock changes stock in one transaction. The products are locked in
+// ID order first, so concurrent orders for the same products queue up
+// instead of deadlocking. Like ratings, stock changes without a new product
+// version, so orders do not conflict with edits and are not kept in the
+// product history.
+func (r *postgresRepository) AdjustStock(ctx context.Context, changes map[string]int64) error {
+	ids := make([]string, 0, len(changes))
+	deltas := make([]int64, 0, len(changes))
+	for id, delta := range changes {
+		ids = append(ids, id)
+		deltas = append(deltas, delta)
+	}
+
+	return r.write(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM products WHERE id = ANY($1) ORDER BY id FOR UPDATE`, ids); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			UPDATE products SET
+				document = jsonb_set(products.document, '{stock}', to_jsonb((products.document->>'stock')::bigint + c.delta))
+			FROM unnest($1::text[], $2::bigint[]) AS c(id, delta)
+			WHERE products.id = c.id AND jsonb_typeof(products.document->'stock') = 'number'
+			RETURNING products.id, (products.document->>'stock')::bigint
+		`, ids, deltas)
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		var updated []string
+		for rows.Next() {
+			var id string
+			var stock int64
+			if err := rows.Scan(&id, &stock); err != nil {
+				return err
+			}
+			if stock < 0 {
+				return ErrInsufficientStock
+			}
+			updated = append(updated, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if len(updated) == 0 {
+			return nil
+		}
+		return enqueueProjection(ctx, tx, updated...)
+	})
+}
+
+// SetStock replaces the stock of a product, or stops tracking it when stock
+// is nil, and records events in the same transaction. Like AdjustStock it
+// does not create a product version.
+func (r *postgresRepository) SetStock(ctx context.Context, productId string, stock *int64, events ...Event) error {
+	return r.write(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE products SET
+				document = CASE
+					WHEN $2::bigint IS NULL THEN document - 'stock'
+					ELSE jsonb_set(document, '{stock}', to_jsonb($2::bigint))
+				END
+			WHERE id = $1
+		`, productId, stock)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		if err := enqueueProjection(ctx, tx, productId); err != nil {
+			return err
+		}
+		return recordEvents(ctx, tx, events...)
+	})
+}
+
+// scanProducts calls fn for every product matching condition. Products are
+// read in pages by ID so fn can write to the products it is given.
+func (r *postgresRepository) scanProducts(ctx context.Context, fn func(Product) error, condition string, args ...interface{}) error {
+	query := fmt.Sprintf(`
+		SELECT id, document, version FROM products
+		WHERE %s AND id > $%d
+		ORDER BY id
+		LIMIT $%d
+	`, condition, len(args)+1, len(args)+2)
+
+	after := ""
+	for {
+		rows, err := r.db.Query(ctx, query, append(args, after, importBatchSize)...)
+		if err != nil {
+			return err
+		}
+		products, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Product, error) {
+			return scanProduct(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, p := range products {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+		if len(products) < importBatchSize {
+			return nil
+		}
+		after = products[len(products)-1].ID
+	}
+}
+
+func scanProduct(row pgx.Row) (Product, error) {
+	var id string
+	var doc ProductDocument
+	var version int64
+	if err := row.Scan(&id, &doc, &version); err != nil {
+		return Product{}, err
+	}
+
+	p := doc.toProduct(id)
+	p.Version = formatProductVersion(version)
+	return p, nil
+}
+
+func formatProductVersion(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+func parseProductVersion(version string) (int64, error) {
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidVersion
+	}
+	return v, nil
+}
@@ -0,0 +1,469 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	PriceReasonCreated   = "created"
+	PriceReasonUpdated   = "updated"
+	PriceReasonImported  = "imported"
+	PriceReasonScheduled = "scheduled"
+
+	priceHistoryIndex = "price_history"
+	maxPriceSchedules = 20
+)
+
+var (
+	ErrInvalidPrice         = errors.New("invalid price")
+	ErrInvalidSchedule      = errors.New("price schedule must end after it starts and in the future")
+	ErrTooManySchedules     = errors.New("too many price schedules")
+	ErrPriceScheduleMissing = errors.New("price schedule not found")
+)
+
+var priceHistoryMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"productId":            map[string]interface{}{"type": "keyword"},
+		"currency":             map[string]interface{}{"type": "keyword"},
+		"priceAmount":          map[string]interface{}{"type": "long"},
+		"compareAtPriceAmount": map[string]interface{}{"type": "long"},
+		"reason":               map[string]interface{}{"type": "keyword"},
+		"changedAt":            map[string]interface{}{"type": "date"},
+	},
+}
+
+// PriceSchedule sets the product price to Price between StartsAt and EndsAt.
+// When schedules overlap the one that started last wins.
+type PriceSchedule struct {
+	ID       string      `json:"id"`
+	Price    money.Money `json:"price"`
+	StartsAt time.Time   `json:"startsAt"`
+	EndsAt   time.Time   `json:"endsAt"`
+}
+
+type PricePoint struct {
+	ProductID      string      `json:"productId"`
+	Price          money.Money `json:"price"`
+	CompareAtPrice money.Money `json:"compareAtPrice"`
+	Reason         string      `json:"reason"`
+	ChangedAt      time.Time   `json:"changedAt"`
+}
+
+// pricePointDocument is how a PricePoint is stored. Amounts are in minor
+// units of Currency.
+type pricePointDocument struct {
+	ProductID            string    `json:"productId"`
+	Currency             string    `json:"currency"`
+	PriceAmount          int64     `json:"priceAmount"`
+	CompareAtPriceAmount int64     `json:"compareAtPriceAmount"`
+	Reason               string    `json:"reason"`
+	ChangedAt            time.Time `json:"changedAt"`
+
+	// Points recorded before prices were stored in minor units
+	LegacyPrice          float64 `json:"price,omitempty"`
+	LegacyCompareAtPrice float64 `json:"compareAtPrice,omitempty"`
+}
+
+func newPricePointDocument(p PricePoint) pricePointDocument {
+	return pricePointDocument{
+		ProductID:            p.ProductID,
+		Currency:             p.Price.Currency,
+		PriceAmount:          p.Price.Amount,
+		CompareAtPriceAmount: p.CompareAtPrice.Amount,
+		Reason:               p.Reason,
+		ChangedAt:            p.ChangedAt,
+	}
+}
+
+func (d pricePointDocument) toPricePoint() PricePoint {
+	point := PricePoint{
+		ProductID:      d.ProductID,
+		Price:          money.New(d.PriceAmount, d.Currency),
+		CompareAtPrice: money.New(d.CompareAtPriceAmount, d.Currency),
+		Reason:         d.Reason,
+		ChangedAt:      d.ChangedAt,
+	}
+	if d.Currency == "" {
+		point.Price = money.FromFloat(d.LegacyPrice, money.DefaultCurrency)
+		point.CompareAtPrice = money.FromFloat(d.LegacyCompareAtPrice, money.DefaultCurrency)
+	}
+	return point
+}
+
+// CompareAtPrice is the regular price while a lower sale price is active,
+// and zero otherwise
+func (p Product) CompareAtPrice() money.Money {
+	if p.Price.Less(p.RegularPrice) {
+		return p.RegularPrice
+	}
+	return money.New(0, p.Price.Currency)
+}
+
+func (p Product) pricePoint(reason string, at time.Time) PricePoint {
+	return PricePoint{
+		ProductID:      p.ID,
+		Price:          p.Price,
+		CompareAtPrice: p.CompareAtPrice(),
+		Reason:         reason,
+		ChangedAt:      at,
+	}
+}
+
+// applyPriceSchedules drops expired schedules, sets Price from the active
+// schedule or the regular price and works out when the price next changes.
+// It reports whether Price changed.
+func (p *Product) applyPriceSchedules(now time.Time) bool {
+	previous := p.Price
+	var active *PriceSchedule
+	var next *time.Time
+
+	var schedules []PriceSchedule
+	for _, schedule := range p.PriceSchedules {
+		if !schedule.EndsAt.After(now) {
+			continue
+		}
+		schedules = append(schedules, schedule)
+
+		change := schedule.EndsAt
+		if schedule.StartsAt.After(now) {
+			change = schedule.StartsAt
+		} else if active == nil || schedule.StartsAt.After(active.StartsAt) {
+			active = &schedule
+		}
+		if next == nil || change.Before(*next) {
+			next = &change
+		}
+	}
+	p.PriceSchedules = schedules
+	p.NextPriceChangeAt = next
+
+	p.Price = p.RegularPrice
+	if active != nil {
+		p.Price = active.Price
+	}
+	return p.Price != previous
+}
+
+// validatePrice returns price with its currency code normalized
+func validatePrice(price money.Money) (money.Money, error) {
+	price, err := price.Validate()
+	if err != nil {
+		return money.Money{}, fmt.Errorf("%w: %v", ErrInvalidPrice, err)
+	}
+	return price, nil
+}
+
+// SchedulePrice adds a sale price that applies between startsAt and endsAt
+func (p productService) SchedulePrice(ctx context.Context, productId, accountId string, price money.Money, startsAt, endsAt time.Time) (*Product, error) {
+	price, err := validatePrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if !endsAt.After(startsAt) || !endsAt.After(now) {
+		return nil, ErrInvalidSchedule
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	if len(product.PriceSchedules) >= maxPriceSchedules {
+		return nil, ErrTooManySchedules
+	}
+
+	if price.Currency != product.RegularPrice.Currency {
+		return nil, money.ErrCurrencyMismatch
+	}
+
+	product.PriceSchedules = append(product.PriceSchedules, PriceSchedule{
+		ID:       uuid.New().String(),
+		Price:    price,
+		StartsAt: startsAt.UTC(),
+		EndsAt:   endsAt.UTC(),
+	})
+
+	return p.savePriceChange(ctx, product, now)
+}
+
+func (p productService) CancelPriceSchedule(ctx context.Context, productId, scheduleId, accountId string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	i := slices.IndexFunc(product.PriceSchedules, func(s PriceSchedule) bool { return s.ID == scheduleId })
+	if i < 0 {
+		return nil, ErrPriceScheduleMissing
+	}
+	product.PriceSchedules = slices.Delete(product.PriceSchedules, i, i+1)
+
+	return p.savePriceChange(ctx, product, time.Now().UTC())
+}
+
+func (p productService) GetPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error) {
+	return p.repo.ListPriceHistory(ctx, productId, skip, take)
+}
+
+// ApplyScheduledPrices updates every product whose price is due to start or
+// stop a scheduled price. Products edited concurrently are picked up again on
+// the next run.
+func (p productService) ApplyScheduledPrices(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	var due []Product
+	err := p.repo.ScanPriceChangesDue(ctx, now, func(product Product) error {
+		due = append(due, product)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for i := range due {
+		if _, err := p.savePriceChange(ctx, &due[i], now); err != nil {
+			log.Printf("Error applying scheduled price for product %s: %v", due[i].ID, err)
+			continue
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// savePriceChange recomputes the effective price and stores the product. If
+// the price changed it is recorded in the price history and consumers are
+// notified.
+func (p productService) savePriceChange(ctx context.Context, product *Product, now time.Time) (*Product, error) {
+	changed := product.applyPriceSchedules(now)
+
+	var events []Event
+	if changed {
+		events = append(events, newProductEvent("product_updated", *product))
+	}
+
+	var err error
+	product.Version, err = p.repo.UpdateProduct(ctx, *product, events...)
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		p.recordPrices(ctx, PriceReasonScheduled, now, *product)
+	}
+
+	return product, nil
+}
+
+// recordPrices appends the current price of each product to the price history
+func (p productService) recordPrices(ctx context.Context, reason string, now time.Time, products ...Product) {
+	points := make([]PricePoint, len(products))
+	for i, product := range products {
+		points[i] = product.pricePoint(reason, now)
+	}
+	if err := p.repo.RecordPrices(ctx, points); err != nil {
+		log.Printf("Error recording price history: %v", err)
+	}
+}
+
+// RunPriceScheduler applies due price schedules every interval until ctx is done
+func RunPriceScheduler(ctx context.Context, s Service, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			applied, err := s.ApplyScheduledPrices(ctx)
+			if err != nil {
+				log.Printf("Error applying scheduled prices: %v", err)
+			} else if applied > 0 {
+				log.Printf("Applied scheduled prices to %d products", applied)
+			}
+		}
+	}
+}
+
+func (r *elasticRepository) ensurePriceHistoryIndex(ctx context.Context) error {
+	return r.ensureIndex(ctx, priceHistoryIndex, priceHistoryMapping)
+}
+
+func (r *elasticRepository) ScanPriceChangesDue(ctx context.Context, now time.Time, fn func(Product) error) error {
+	return r.scanProducts(ctx, map[string]interface{}{
+		"range": map[string]interface{}{
+			"nextPriceChangeAt": map[string]interface{}{
+				"lte": now.Format(time.RFC3339),
+			},
+		},
+	}, fn)
+}
+
+func (r *elasticRepository) RecordPrices(ctx context.Context, points []PricePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, point := range points {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{"_index": priceHistoryIndex},
+		}
+		if err := enc.Encode(action); err != nil {
+			return err
+		}
+		if err := enc.Encode(newPricePointDocument(point)); err != nil {
+			return err
+		}
+	}
+
+	res, err := r.client.Bulk(
+		&body,
+		r.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to record price history: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.Errors {
+		return errors.New("failed to record some price history entries")
+	}
+	return nil
+}
+
+func (r *elasticRepository) ListPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"productId": productId},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"changedAt": "desc"},
+		},
+		"from": skip,
+		"size": take,
+	}
+
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(priceHistoryIndex),
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("price history search failed: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				Source pricePointDocument `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	points := []PricePoint{}
+	for _, hit := range result.Hits.Hits {
+		points = append(points, hit.Source.toPricePoint())
+	}
+	return points, nil
+}
+
+func (r *postgresRepository) RecordPrices(ctx context.Context, points []PricePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var productIds, currencies, reasons []string
+	var prices, compareAtPrices []int64
+	var changedAt []time.Time
+	for _, point := range points {
+		doc := newPricePointDocument(point)
+		productIds = append(productIds, doc.ProductID)
+		currencies = append(currencies, doc.Currency)
+		prices = append(prices, doc.PriceAmount)
+		compareAtPrices = append(compareAtPrices, doc.CompareAtPriceAmount)
+		reasons = append(reasons, doc.Reason)
+		changedAt = append(changedAt, doc.ChangedAt)
+	}
+
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO price_history (product_id, currency, price_amount, compare_at_price_amount, reason, changed_at)
+		SELECT * FROM unnest($1::text[], $2::text[], $3::bigint[], $4::bigint[], $5::text[], $6::timestamptz[])
+	`, productIds, currencies, prices, compareAtPrices, reasons, changedAt)
+	return err
+}
+
+func (r *postgresRepository) ListPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT product_id, currency, price_amount, compare_at_price_amount, reason, changed_at
+		FROM price_history
+		WHERE product_id = $1
+		ORDER BY changed_at DESC, id DESC
+		OFFSET $2 LIMIT $3
+	`, productId, skip, take)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (pricePointDocument, error) {
+		var d pricePointDocument
+		err := row.Scan(&d.ProductID, &d.Currency, &d.PriceAmount, &d.CompareAtPriceAmount, &d.Reason, &d.ChangedAt)
+		return d, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := []PricePoint{}
+	for _, d := range docs {
+		points = append(points, d.toPricePoint())
+	}
+	return points, nil
+}
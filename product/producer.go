@@ -5,14 +5,17 @@ import (
 	"log"
 
 	"github.com/IBM/sarama"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 )
 
 type EventData struct {
-	ID          *string  `json:"product_id"`
-	Name        *string  `json:"name"`
-	Description *string  `json:"description"`
-	Price       *float64 `json:"price"`
-	AccountID   *string  `json:"accountID"`
+	ID          *string      `json:"product_id"`
+	Name        *string      `json:"name"`
+	Description *string      `json:"description"`
+	Price       *money.Money `json:"price"`
+	AccountID   *string      `json:"accountID"`
+	// UserID is the shopper behind an interaction event
+	UserID *string `json:"user_id,omitempty"`
 }
 
 type Event struct {
@@ -20,8 +23,28 @@ type Event struct {
 	Data EventData `json:"data"`
 }
 
+// productEventsTopic receives the events recorded with product writes
+const productEventsTopic = "product_events"
+
+// newProductEvent describes a write of p
+func newProductEvent(eventType string, p Product) Event {
+	return Event{
+		Type: eventType,
+		Data: EventData{
+			ID:          &p.ID,
+			Name:        &p.Name,
+			Description: &p.Description,
+			Price:       &p.Price,
+			AccountID:   &p.AccountID,
+		},
+	}
+}
+
 var done = make(chan bool)
 
+// SendMessageToRecommender publishes event without waiting for the broker.
+// It is only used for interaction events, which may be lost; product writes
+// record their events in the outbox instead.
 func (p productService) SendMessageToRecommender(event Event, topic string) error {
 	jsonMessage, err := json.Marshal(event)
 	if err != nil {
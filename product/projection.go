@@ -0,0 +1,542 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const projectionBatchSize = 100
+
+// catalogWrite is a version of a product to write to a catalog index. A nil
+// Document deletes the product from the index.
+type catalogWrite struct {
+	ID       string
+	Document *ProductDocument
+	Version  int64
+}
+
+// CatalogDrift lists products whose catalog document does not match
+// Postgres. Products waiting in the projection queue are expected to differ
+// and are only counted in Pending.
+type CatalogDrift struct {
+	Checked  int
+	Pending  int
+	Missing  []string
+	Stale    []string
+	Orphaned []string
+}
+
+func (d CatalogDrift) Count() int {
+	return len(d.Missing) + len(d.Stale) + len(d.Orphaned)
+}
+
+// ProjectCatalog writes queued products to the catalog index with their
+// Postgres version as an external version, so an older version never
+// replaces a newer one. Queue rows are locked while they are written, and
+// failed rows move to the back of the queue.
+func (r *postgresRepository) ProjectCatalog(ctx context.Context, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT q.product_id, p.document, p.version
+		FROM catalog_projection_queue q
+		LEFT JOIN products p ON p.id = q.product_id
+		ORDER BY q.enqueued_at
+		LIMIT $1
+		FOR UPDATE OF q SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+	writes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (catalogWrite, error) {
+		var w catalogWrite
+		var version *int64
+		if err := row.Scan(&w.ID, &w.Document, &version); err != nil {
+			return w, err
+		}
+		if version != nil {
+			w.Version = *version
+		}
+		return w, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(writes) == 0 {
+		return 0, nil
+	}
+
+	itemErrors, err := r.writeCatalog(ctx, catalogAlias, writes)
+	if err != nil {
+		return 0, err
+	}
+
+	var projected []string
+	for i, w := range writes {
+		if itemErrors[i] == nil {
+			projected = append(projected, w.ID)
+			continue
+		}
+
+		log.Printf("Error projecting product %s: %v", w.ID, itemErrors[i])
+		_, err := tx.Exec(ctx, `
+			UPDATE catalog_projection_queue SET
+				attempts = attempts + 1,
+				last_error = $2,
+				enqueued_at = NOW()
+			WHERE product_id = $1
+		`, w.ID, itemErrors[i].Error())
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM catalog_projection_queue WHERE product_id = ANY($1)`, projected); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(projected), nil
+}
+
+// RunCatalogProjector projects queued products into the catalog index after
+// every write, and every interval to retry failures, until ctx is done
+func RunCatalogProjector(ctx context.Context, r PostgresRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			projected, err := r.ProjectCatalog(ctx, projectionBatchSize)
+			if err != nil {
+				log.Printf("Error projecting catalog: %v", err)
+				break
+			}
+			if projected < projectionBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.ProjectionPending():
+		}
+	}
+}
+
+// RebuildCatalog builds a new catalog index from Postgres and moves the alias
+// to it. Writes projected into the old index while the rebuild ran are
+// caught up by a repairing consistency check once the alias has moved. The
+// synonyms set is restored from Postgres first, since it is empty on a new
+// cluster.
+func (r *postgresRepository) RebuildCatalog(ctx context.Context, deleteOld bool) (string, error) {
+	current, err := r.catalogIndices(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var rules []string
+	err = r.getSetting(ctx, synonymsSetting, &rules)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+	if err == nil {
+		if err := r.elasticRepository.PutSynonyms(ctx, rules); err != nil {
+			return "", err
+		}
+	}
+
+	index := newCatalogIndexName()
+	log.Printf("Rebuilding catalog into %s", index)
+	if err := r.createCatalogIndex(ctx, index); err != nil {
+		return "", err
+	}
+
+	written := 0
+	var batch []catalogWrite
+	flush := func() error {
+		itemErrors, err := r.writeCatalog(ctx, index, batch)
+		if err != nil {
+			return err
+		}
+		for i, err := range itemErrors {
+			if err != nil {
+				return fmt.Errorf("failed to index product %s: %w", batch[i].ID, err)
+			}
+		}
+		written += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	err = r.scanProducts(ctx, func(p Product) error {
+		doc := newProductDocument(p)
+		version, _ := parseProductVersion(p.Version)
+		batch = append(batch, catalogWrite{ID: p.ID, Document: &doc, Version: version})
+		if len(batch) < importBatchSize {
+			return nil
+		}
+		return flush()
+	}, `TRUE`)
+	if err != nil {
+		return "", err
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return "", err
+		}
+	}
+	log.Printf("Indexed %d products into %s", written, index)
+
+	actions := []map[string]interface{}{
+		{"add": map[string]interface{}{"index": index, "alias": catalogAlias, "is_write_index": true}},
+	}
+	for _, old := range current {
+		if old == catalogAlias {
+			actions = append(actions, map[string]interface{}{"remove_index": map[string]interface{}{"index": old}})
+		} else {
+			actions = append(actions, map[string]interface{}{"remove": map[string]interface{}{"index": old, "alias": catalogAlias}})
+		}
+	}
+	if err := r.updateAliases(ctx, actions...); err != nil {
+		return "", err
+	}
+	log.Printf("Catalog alias now points to %s", index)
+
+	drift, err := r.CheckCatalog(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	if drift.Count() > 0 {
+		log.Printf("Queued %d products changed during the rebuild", drift.Count())
+	}
+
+	if deleteOld {
+		for _, old := range current {
+			if old == catalogAlias {
+				continue
+			}
+			res, err := r.client.Indices.Delete([]string{old}, r.client.Indices.Delete.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+			res.Body.Close()
+
+			if res.IsError() {
+				return "", fmt.Errorf("failed to delete index %s: %s", old, res.String())
+			}
+			log.Printf("Deleted index %s", old)
+		}
+	}
+
+	return index, nil
+}
+
+// CheckCatalog compares the version of every product in Postgres with its
+// catalog document. With repair, drifted products are queued for projection,
+// which also removes orphaned documents.
+func (r *postgresRepository) CheckCatalog(ctx context.Context, repair bool) (*CatalogDrift, error) {
+	pending := map[string]bool{}
+	rows, err := r.db.Query(ctx, `SELECT product_id FROM catalog_projection_queue`)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	versions := map[string]int64{}
+	rows, err = r.db.Query(ctx, `SELECT id, version FROM products`)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id string
+		var version int64
+		if err := rows.Scan(&id, &version); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		versions[id] = version
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	drift := &CatalogDrift{Checked: len(versions), Pending: len(pending)}
+	err = r.scrollCatalog(ctx, map[string]interface{}{
+		"query":   map[string]interface{}{"match_all": map[string]interface{}{}},
+		"_source": false,
+		"version": true,
+	}, func(hit scrollHit) error {
+		version, ok := versions[hit.ID]
+		delete(versions, hit.ID)
+		switch {
+		case pending[hit.ID]:
+		case !ok:
+			drift.Orphaned = append(drift.Orphaned, hit.ID)
+		case hit.Version != version:
+			drift.Stale = append(drift.Stale, hit.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for id := range versions {
+		if !pending[id] {
+			drift.Missing = append(drift.Missing, id)
+		}
+	}
+
+	if repair && drift.Count() > 0 {
+		var drifted []string
+		drifted = append(drifted, drift.Missing...)
+		drifted = append(drifted, drift.Stale...)
+		drifted = append(drifted, drift.Orphaned...)
+		err := r.write(ctx, func(tx pgx.Tx) error {
+			return enqueueProjection(ctx, tx, drifted...)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return drift, nil
+}
+
+// SeedFromCatalog copies products that only exist in the catalog index into
+// Postgres, keeping their index version so the projection can overwrite
+// them. It is meant for moving an Elasticsearch-only catalog to Postgres.
+func (r *postgresRepository) SeedFromCatalog(ctx context.Context) (int, error) {
+	seeded := 0
+	err := r.scrollCatalog(ctx, map[string]interface{}{
+		"query":   map[string]interface{}{"match_all": map[string]interface{}{}},
+		"version": true,
+	}, func(hit scrollHit) error {
+		p := hit.Source.toProduct(hit.ID)
+		doc, err := json.Marshal(newProductDocument(p))
+		if err != nil {
+			return err
+		}
+
+		// Seeded products start their history at their index version
+		tag, err := r.db.Exec(ctx, `
+			WITH seeded AS (
+				INSERT INTO products (id, account_id, document, version, archived_at, next_price_change_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (id) DO NOTHING
+				RETURNING id, version, document
+			)
+			INSERT INTO product_versions (product_id, version, document)
+			SELECT id, version, document FROM seeded
+		`, p.ID, p.AccountID, doc, hit.Version, p.ArchivedAt, p.NextPriceChangeAt)
+		if err != nil {
+			return err
+		}
+		seeded += int(tag.RowsAffected())
+		return nil
+	})
+	return seeded, err
+}
+
+// SeedReviewsAndSettings copies reviews, price history, category schemas and
+// catalog settings from their indices into Postgres, keeping what Postgres
+// already has. Run it after SeedFromCatalog: reviews and prices of products
+// missing from Postgres are skipped. It returns how many rows were copied.
+func (r *postgresRepository) SeedReviewsAndSettings(ctx context.Context) (int, error) {
+	seeded := 0
+	count := func(tag pgconn.CommandTag, err error) error {
+		seeded += int(tag.RowsAffected())
+		return err
+	}
+	matchAll := func() map[string]interface{} {
+		return map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}
+	}
+
+	err := r.scrollHits(ctx, reviewIndex, matchAll(), func(raw json.RawMessage) error {
+		var hit struct {
+			ID     string `json:"_id"`
+			Source Review `json:"_source"`
+		}
+		if err := json.Unmarshal(raw, &hit); err != nil {
+			return err
+		}
+		review := hit.Source
+		return count(r.db.Exec(ctx, `
+			INSERT INTO product_reviews (id, product_id, account_id, rating, title, body, verified_purchase, status, reply, helpful_voters, created_at)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10::text[], '{}'), $11
+			WHERE EXISTS (SELECT 1 FROM products WHERE id = $2)
+			ON CONFLICT (id) DO NOTHING
+		`, hit.ID, review.ProductID, review.AccountID, review.Rating, review.Title, review.Body,
+			review.VerifiedPurchase, review.Status, review.Reply, review.HelpfulVoters, review.CreatedAt))
+	})
+	if err != nil {
+		return seeded, err
+	}
+
+	// Price points have no ID, so a point is only copied if its product has
+	// no point with the same time and reason
+	err = r.scrollHits(ctx, priceHistoryIndex, matchAll(), func(raw json.RawMessage) error {
+		var hit struct {
+			Source pricePointDocument `json:"_source"`
+		}
+		if err := json.Unmarshal(raw, &hit); err != nil {
+			return err
+		}
+		doc := newPricePointDocument(hit.Source.toPricePoint())
+		return count(r.db.Exec(ctx, `
+			INSERT INTO price_history (product_id, currency, price_amount, compare_at_price_amount, reason, changed_at)
+			SELECT $1, $2, $3, $4, $5, $6
+			WHERE EXISTS (SELECT 1 FROM products WHERE id = $1)
+				AND NOT EXISTS (SELECT 1 FROM price_history WHERE product_id = $1 AND changed_at = $6 AND reason = $5)
+		`, doc.ProductID, doc.Currency, doc.PriceAmount, doc.CompareAtPriceAmount, doc.Reason, doc.ChangedAt))
+	})
+	if err != nil {
+		return seeded, err
+	}
+
+	err = r.scrollHits(ctx, categorySchemasIndex, matchAll(), func(raw json.RawMessage) error {
+		var hit struct {
+			Source json.RawMessage `json:"_source"`
+		}
+		if err := json.Unmarshal(raw, &hit); err != nil {
+			return err
+		}
+		var schema CategorySchema
+		if err := json.Unmarshal(hit.Source, &schema); err != nil {
+			return err
+		}
+		return count(r.db.Exec(ctx, `
+			INSERT INTO category_schemas (category, schema, updated_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (category) DO NOTHING
+		`, schema.Category, hit.Source, schema.UpdatedAt))
+	})
+	if err != nil {
+		return seeded, err
+	}
+
+	settings := map[string]func() (interface{}, error){
+		exchangeRatesSetting:  func() (interface{}, error) { return r.elasticRepository.GetExchangeRates(ctx) },
+		screeningRulesSetting: func() (interface{}, error) { return r.elasticRepository.GetScreeningRules(ctx) },
+		synonymsSetting:       func() (interface{}, error) { return r.elasticRepository.GetSynonyms(ctx) },
+	}
+	for key, get := range settings {
+		value, err := get()
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return seeded, err
+		}
+		doc, err := json.Marshal(value)
+		if err != nil {
+			return seeded, err
+		}
+		err = count(r.db.Exec(ctx, `
+			INSERT INTO catalog_settings (key, value)
+			VALUES ($1, $2)
+			ON CONFLICT (key) DO NOTHING
+		`, key, doc))
+		if err != nil {
+			return seeded, err
+		}
+	}
+	return seeded, nil
+}
+
+// writeCatalog indexes or deletes documents in index with external versions.
+// A document at the version the index holds replaces it, since popularity
+// changes without a new version. A document the index already holds at a
+// newer version counts as written.
+func (r *elasticRepository) writeCatalog(ctx context.Context, index string, writes []catalogWrite) ([]error, error) {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, w := range writes {
+		if w.Document == nil {
+			action := map[string]interface{}{
+				"delete": map[string]interface{}{"_index": index, "_id": w.ID},
+			}
+			if err := enc.Encode(action); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		action := map[string]interface{}{
+			"index": map[string]interface{}{
+				"_index":       index,
+				"_id":          w.ID,
+				"version":      w.Version,
+				"version_type": "external_gte",
+			},
+		}
+		if err := enc.Encode(action); err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(w.Document); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := r.client.Bulk(
+		&body,
+		r.client.Bulk.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("bulk request failed: %s", res.String())
+	}
+
+	var result struct {
+		Items []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	itemErrors := make([]error, len(writes))
+	for i, item := range result.Items {
+		if i >= len(itemErrors) {
+			break
+		}
+		for _, op := range item {
+			// 409: already at a newer version, 404: already deleted
+			if op.Error != nil && op.Status != 409 && op.Status != 404 {
+				itemErrors[i] = fmt.Errorf("%s: %s", op.Error.Type, op.Error.Reason)
+			}
+		}
+	}
+	return itemErrors, nil
+}
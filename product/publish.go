@@ -0,0 +1,172 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// Listing statuses. Only published products past their publish time are
+// shown in listings and search.
+const (
+	StatusDraft         = "draft"
+	StatusPendingReview = "pending_review"
+	StatusPublished     = "published"
+	StatusUnpublished   = "unpublished"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("product cannot be moved to that status")
+	ErrNotPublished            = errors.New("product is not published")
+)
+
+// IsPublished reports whether shoppers can see and buy the product at now
+func (p Product) IsPublished(now time.Time) bool {
+	return p.Status == StatusPublished && p.ArchivedAt == nil && !p.Held() && (p.PublishAt == nil || !p.PublishAt.After(now))
+}
+
+// PublishProduct lists a draft or unpublished product, at publishAt if it is
+// in the future. When listings are reviewed the product waits in
+// StatusPendingReview until ReviewProduct approves it. Publishing an already
+// published product only moves its publish time.
+func (p productService) PublishProduct(ctx context.Context, productId, accountId string, publishAt *time.Time) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+	if product.ArchivedAt != nil {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	now := time.Now().UTC()
+	if publishAt != nil && publishAt.After(now) {
+		at := publishAt.UTC()
+		product.PublishAt = &at
+	} else {
+		product.PublishAt = nil
+	}
+	if product.Status != StatusPublished {
+		product.Status = StatusPublished
+		if p.reviewListings {
+			product.Status = StatusPendingReview
+		}
+	}
+
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("PublishProduct: %s is %s", product.ID, product.Status)
+	return product, nil
+}
+
+// UnpublishProduct takes a product out of listings and search, or withdraws
+// it from review
+func (p productService) UnpublishProduct(ctx context.Context, productId, accountId string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	switch product.Status {
+	case StatusUnpublished:
+		return product, nil
+	case StatusPublished, StatusPendingReview:
+	default:
+		return nil, ErrInvalidStatusTransition
+	}
+
+	product.Status = StatusUnpublished
+	product.PublishAt = nil
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ReviewProduct publishes a product waiting for review, or sends it back to
+// the seller as a draft
+func (p productService) ReviewProduct(ctx context.Context, productId string, approve bool) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.Status != StatusPendingReview {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	if approve {
+		product.Status = StatusPublished
+	} else {
+		product.Status = StatusDraft
+		product.PublishAt = nil
+	}
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("ReviewProduct: %s is %s", product.ID, product.Status)
+	return product, nil
+}
+
+// reviewEdit sends a published product back to review when listings are
+// reviewed and an edit changes what shoppers were approved to see: its name,
+// description or price
+func (p productService) reviewEdit(before Product, after *Product) {
+	if !p.reviewListings || before.Status != StatusPublished {
+		return
+	}
+	if after.Name != before.Name || after.Description != before.Description || after.RegularPrice != before.RegularPrice {
+		after.Status = StatusPendingReview
+	}
+}
+
+// hiddenProducts matches products shoppers cannot see: archived, not
+// published, published with a publish time still in the future, or held by
+// screening. Documents written before products had a status have none and
+// count as published.
+func hiddenProducts() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"exists": map[string]interface{}{"field": "archivedAt"},
+		},
+		map[string]interface{}{
+			"terms": map[string]interface{}{
+				"status": []string{StatusDraft, StatusPendingReview, StatusUnpublished},
+			},
+		},
+		map[string]interface{}{
+			"range": map[string]interface{}{
+				"publishAt": map[string]interface{}{"gt": "now"},
+			},
+		},
+		map[string]interface{}{
+			"terms": map[string]interface{}{
+				"screening.status": []string{ScreeningFlagged, ScreeningRejected},
+			},
+		},
+	}
+}
+
+// onlyPublished restricts query to products shoppers can see
+func onlyPublished(query map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"bool": map[string]interface{}{
+			"must":     query,
+			"must_not": hiddenProducts(),
+		},
+	}
+}
@@ -0,0 +1,390 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// sellerNotificationsTopic carries messages for sellers, keyed by seller
+	sellerNotificationsTopic = "seller_notifications"
+
+	maxQuestionLength = 1000
+	maxAnswerLength   = 2000
+)
+
+var (
+	ErrInvalidQuestion = errors.New("question must be between 1 and 1000 characters")
+	ErrInvalidAnswer   = errors.New("answer must be between 1 and 2000 characters")
+	ErrOwnQuestion     = errors.New("sellers cannot ask questions about their own products")
+	ErrNotBuyer        = errors.New("only the seller and buyers of the product can answer questions")
+	ErrNoQuestions     = errors.New("questions are only kept by the Postgres repository")
+)
+
+// Answer to a product question, by the seller of the product or by one of
+// its buyers
+type Answer struct {
+	ID               string    `json:"id"`
+	QuestionID       string    `json:"questionId"`
+	AccountID        string    `json:"accountId"`
+	Body             string    `json:"body"`
+	FromSeller       bool      `json:"fromSeller"`
+	VerifiedPurchase bool      `json:"verifiedPurchase"`
+	Status           string    `json:"status"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// Question asked by a shopper about a product. Questions and answers are
+// moderated like reviews and only listed while approved.
+type Question struct {
+	ID        string    `json:"id"`
+	ProductID string    `json:"productId"`
+	AccountID string    `json:"accountId"`
+	Body      string    `json:"body"`
+	Status    string    `json:"status"`
+	Answers   []Answer  `json:"answers"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// approved returns q with only its approved answers
+func (q Question) approved() Question {
+	answers := []Answer{}
+	for _, a := range q.Answers {
+		if a.Status == ReviewApproved {
+			answers = append(answers, a)
+		}
+	}
+	q.Answers = answers
+	return q
+}
+
+// SellerNotification tells a seller about activity on one of their products
+type SellerNotification struct {
+	Type       string    `json:"type"`
+	SellerID   string    `json:"seller_id"`
+	ProductID  string    `json:"product_id"`
+	QuestionID string    `json:"question_id"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// QuestionRepository stores product questions. Questions are read with
+// their answers, oldest answer first.
+type QuestionRepository interface {
+	// CreateQuestion stores q and records notification for the seller in the
+	// same transaction
+	CreateQuestion(ctx context.Context, q Question, notification SellerNotification) error
+	// GetQuestion returns the question with all of its answers, whatever
+	// their status
+	GetQuestion(ctx context.Context, id string) (*Question, error)
+	// ListQuestions returns the approved questions of a product, newest
+	// first, with their approved answers
+	ListQuestions(ctx context.Context, productId string, skip, take uint64) ([]Question, error)
+	CreateAnswer(ctx context.Context, a Answer) error
+	SetQuestionStatus(ctx context.Context, id, status string) error
+	// SetAnswerStatus returns the question the answer belongs to
+	SetAnswerStatus(ctx context.Context, id, status string) (string, error)
+}
+
+func (p productService) questionRepository() (QuestionRepository, error) {
+	if p.questions == nil {
+		return nil, ErrNoQuestions
+	}
+	return p.questions, nil
+}
+
+// AskQuestion records a question about a published product and notifies its
+// seller
+func (p productService) AskQuestion(ctx context.Context, productId, accountId, body string) (*Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" || len(body) > maxQuestionLength {
+		return nil, ErrInvalidQuestion
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsPublished(time.Now()) {
+		return nil, ErrNotPublished
+	}
+	if product.AccountID == accountId {
+		return nil, ErrOwnQuestion
+	}
+
+	q := Question{
+		ID:        uuid.New().String(),
+		ProductID: productId,
+		AccountID: accountId,
+		Body:      body,
+		Status:    ReviewApproved,
+		Answers:   []Answer{},
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err = repo.CreateQuestion(ctx, q, SellerNotification{
+		Type:       "question_posted",
+		SellerID:   product.AccountID,
+		ProductID:  productId,
+		QuestionID: q.ID,
+		Body:       body,
+		CreatedAt:  q.CreatedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (p productService) GetProductQuestions(ctx context.Context, productId string, skip, take uint64) ([]Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+	return repo.ListQuestions(ctx, productId, skip, take)
+}
+
+// GetQuestion returns an approved question with its approved answers
+func (p productService) GetQuestion(ctx context.Context, id string) (*Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := repo.GetQuestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != ReviewApproved {
+		return nil, ErrNotFound
+	}
+	approved := q.approved()
+	return &approved, nil
+}
+
+// AnswerQuestion records an answer by the seller of the product or, when
+// verifiedPurchase is set, by one of its buyers. verifiedPurchase is
+// determined by the caller, which has access to the account's order history.
+func (p productService) AnswerQuestion(ctx context.Context, questionId, accountId, body string, verifiedPurchase bool) (*Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" || len(body) > maxAnswerLength {
+		return nil, ErrInvalidAnswer
+	}
+
+	q, err := repo.GetQuestion(ctx, questionId)
+	if err != nil {
+		return nil, err
+	}
+	if q.Status != ReviewApproved {
+		return nil, ErrNotFound
+	}
+
+	product, err := p.repo.GetProductById(ctx, q.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	fromSeller := product.AccountID == accountId
+	if !fromSeller && !verifiedPurchase {
+		return nil, ErrNotBuyer
+	}
+
+	a := Answer{
+		ID:               uuid.New().String(),
+		QuestionID:       questionId,
+		AccountID:        accountId,
+		Body:             body,
+		FromSeller:       fromSeller,
+		VerifiedPurchase: verifiedPurchase,
+		Status:           ReviewApproved,
+		CreatedAt:        time.Now().UTC(),
+	}
+	if err := repo.CreateAnswer(ctx, a); err != nil {
+		return nil, err
+	}
+
+	q.Answers = append(q.Answers, a)
+	answered := q.approved()
+	return &answered, nil
+}
+
+// ModerateQuestion approves or rejects a question. Rejected questions are
+// hidden with their answers and cannot be answered.
+func (p productService) ModerateQuestion(ctx context.Context, questionId, status string) (*Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+	if status != ReviewApproved && status != ReviewRejected {
+		return nil, ErrInvalidReviewStatus
+	}
+
+	if err := repo.SetQuestionStatus(ctx, questionId, status); err != nil {
+		return nil, err
+	}
+	return repo.GetQuestion(ctx, questionId)
+}
+
+// ModerateAnswer approves or rejects an answer and returns its question
+func (p productService) ModerateAnswer(ctx context.Context, answerId, status string) (*Question, error) {
+	repo, err := p.questionRepository()
+	if err != nil {
+		return nil, err
+	}
+	if status != ReviewApproved && status != ReviewRejected {
+		return nil, ErrInvalidReviewStatus
+	}
+
+	questionId, err := repo.SetAnswerStatus(ctx, answerId, status)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetQuestion(ctx, questionId)
+}
+
+func (r *postgresRepository) CreateQuestion(ctx context.Context, q Question, notification SellerNotification) error {
+	return r.write(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO product_questions (id, product_id, account_id, body, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, q.ID, q.ProductID, q.AccountID, q.Body, q.Status, q.CreatedAt)
+		if err != nil {
+			return err
+		}
+		return recordMessage(ctx, tx, sellerNotificationsTopic, notification.SellerID, notification)
+	})
+}
+
+func (r *postgresRepository) GetQuestion(ctx context.Context, id string) (*Question, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, product_id, account_id, body, status, created_at
+		FROM product_questions
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := pgx.CollectRows(rows, scanQuestion)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	if err := r.addAnswers(ctx, questions, false); err != nil {
+		return nil, err
+	}
+	return &questions[0], nil
+}
+
+func (r *postgresRepository) ListQuestions(ctx context.Context, productId string, skip, take uint64) ([]Question, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, product_id, account_id, body, status, created_at
+		FROM product_questions
+		WHERE product_id = $1 AND status = $2
+		ORDER BY created_at DESC, id
+		OFFSET $3 LIMIT $4
+	`, productId, ReviewApproved, skip, take)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := pgx.CollectRows(rows, scanQuestion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.addAnswers(ctx, questions, true); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// addAnswers reads the answers of questions in one query
+func (r *postgresRepository) addAnswers(ctx context.Context, questions []Question, approvedOnly bool) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(questions))
+	byID := map[string]*Question{}
+	for i := range questions {
+		ids[i] = questions[i].ID
+		byID[questions[i].ID] = &questions[i]
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT id, question_id, account_id, body, from_seller, verified_purchase, status, created_at
+		FROM product_answers
+		WHERE question_id = ANY($1) AND (NOT $2 OR status = $3)
+		ORDER BY created_at, id
+	`, ids, approvedOnly, ReviewApproved)
+	if err != nil {
+		return err
+	}
+	answers, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Answer, error) {
+		var a Answer
+		err := row.Scan(&a.ID, &a.QuestionID, &a.AccountID, &a.Body, &a.FromSeller, &a.VerifiedPurchase, &a.Status, &a.CreatedAt)
+		return a, err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, a := range answers {
+		q := byID[a.QuestionID]
+		q.Answers = append(q.Answers, a)
+	}
+	return nil
+}
+
+func scanQuestion(row pgx.CollectableRow) (Question, error) {
+	q := Question{Answers: []Answer{}}
+	err := row.Scan(&q.ID, &q.ProductID, &q.AccountID, &q.Body, &q.Status, &q.CreatedAt)
+	return q, err
+}
+
+func (r *postgresRepository) CreateAnswer(ctx context.Context, a Answer) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO product_answers (id, question_id, account_id, body, from_seller, verified_purchase, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, a.ID, a.QuestionID, a.AccountID, a.Body, a.FromSeller, a.VerifiedPurchase, a.Status, a.CreatedAt)
+	return err
+}
+
+func (r *postgresRepository) SetQuestionStatus(ctx context.Context, id, status string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE product_questions SET status = $2 WHERE id = $1`, id, status)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) SetAnswerStatus(ctx context.Context, id, status string) (string, error) {
+	var questionId string
+	err := r.db.QueryRow(ctx, `
+		UPDATE product_answers SET status = $2 WHERE id = $1
+		RETURNING question_id
+	`, id, status).Scan(&questionId)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	return questionId, err
+}
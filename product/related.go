@@ -0,0 +1,55 @@
+package product
+
+import (
+	"context"
+)
+
+const (
+	defaultRelatedSize = 10
+	maxRelatedSize     = 50
+)
+
+// GetRelatedProducts returns published products similar to productId in
+// name, description and category. Unlike recommendations it needs no
+// interaction data, so it also works for products nobody has viewed yet.
+func (p productService) GetRelatedProducts(ctx context.Context, productId string, size int) ([]Product, error) {
+	if _, err := p.repo.GetProductById(ctx, productId); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		size = defaultRelatedSize
+	}
+	size = min(size, maxRelatedSize)
+
+	products, err := p.repo.RelatedProducts(ctx, productId, size)
+	if err != nil {
+		return nil, err
+	}
+	return p.deriveBundleStock(ctx, products)
+}
+
+func (r *elasticRepository) RelatedProducts(ctx context.Context, productId string, size int) ([]Product, error) {
+	moreLikeThis := map[string]interface{}{
+		"more_like_this": map[string]interface{}{
+			"fields": []string{"name", "description", "category"},
+			"like": []interface{}{
+				map[string]interface{}{"_index": catalogAlias, "_id": productId},
+			},
+			// Catalogs are small enough that a term used once still says
+			// something about the product
+			"min_term_freq":   1,
+			"min_doc_freq":    1,
+			"max_query_terms": 25,
+		},
+	}
+
+	query := map[string]interface{}{
+		"query":               onlyPublished(moreLikeThis),
+		"size":                size,
+		"seq_no_primary_term": true,
+		"version":             true,
+	}
+
+	return r.searchCatalog(ctx, query)
+}
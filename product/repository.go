@@ -7,37 +7,261 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 )
 
 var (
-	ErrNotFound = errors.New("entity not found")
+	ErrNotFound        = errors.New("entity not found")
+	ErrVersionConflict = errors.New("product was modified concurrently, reload and retry")
+	ErrInvalidVersion  = errors.New("invalid product version")
 )
 
 type Repository interface {
 	Close()
-	PutProduct(ctx context.Context, p Product) error
+	PutProduct(ctx context.Context, p Product, events ...Event) (string, error)
 	GetProductById(ctx context.Context, id string) (*Product, error)
 	ListProducts(ctx context.Context, skip, take uint64) ([]Product, error)
 	ListProductsWithIds(ctx context.Context, ids []string) ([]Product, error)
-	SearchProducts(ctx context.Context, query string, skip, take uint64) ([]Product, error)
-	UpdateProduct(ctx context.Context, updatedProduct Product) error
+	ListProductsByAccount(ctx context.Context, accountId string, statuses []string, archived *bool, skip, take uint64) ([]Product, error)
+	SearchProducts(ctx context.Context, query string, skip, take uint64, minRating float64, sort string, attributes []AttributeFilter) ([]Product, error)
+	SearchProductsPage(ctx context.Context, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error)
+	SuggestProducts(ctx context.Context, prefix string, size int) (*Suggestions, error)
+	RelatedProducts(ctx context.Context, productId string, size int) ([]Product, error)
+	ListBundlesContaining(ctx context.Context, productId string) ([]string, error)
+	FindDuplicateProducts(ctx context.Context, p Product, size int) ([]string, error)
+	ListFlaggedProducts(ctx context.Context, skip, take uint64) ([]Product, error)
+	BulkPutProducts(ctx context.Context, products []Product, events []Event) ([]error, error)
+	ScanProductsByAccount(ctx context.Context, accountId string, fn func(Product) error) error
+	GetSynonyms(ctx context.Context) ([]string, error)
+	PutSynonyms(ctx context.Context, rules []string) error
+	UpdateProduct(ctx context.Context, updatedProduct Product, events ...Event) (string, error)
 	DeleteProduct(ctx context.Context, productId string) error
+	ScanArchivedProducts(ctx context.Context, before time.Time, fn func(Product) error) error
+	ReindexCatalog(ctx context.Context, deleteOld bool) (string, error)
+	CreateReview(ctx context.Context, review Review) (string, error)
+	GetReview(ctx context.Context, id string) (*Review, error)
+	UpdateReview(ctx context.Context, review Review) (string, error)
+	ListReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error)
+	RefreshProductRating(ctx context.Context, productId string) error
+	ScanPriceChangesDue(ctx context.Context, now time.Time, fn func(Product) error) error
+	RecordPrices(ctx context.Context, points []PricePoint) error
+	ListPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error)
+	GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error)
+	PutExchangeRates(ctx context.Context, rates money.ExchangeRates) error
+	GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error)
+	PutCategorySchema(ctx context.Context, schema CategorySchema) error
+	GetScreeningRules(ctx context.Context) (*ScreeningRules, error)
+	PutScreeningRules(ctx context.Context, rules ScreeningRules) error
 }
 
 type elasticRepository struct {
 	client *elasticsearch.Client
+	// externalVersions is set when catalog documents are written with the
+	// version of the product in Postgres, see postgresRepository
+	externalVersions bool
+	// firstPagePITs holds a slot for every first page of SearchProductsPage
+	// whose point in time may still be open
+	firstPagePITs chan struct{}
 }
 
+// ProductDocument is how a Product is stored. Prices are amounts in minor
+// units of Currency.
 type ProductDocument struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	AccountID   string  `json:"accountId"`
+	// ID repeats the document _id so listings can use it as a sort tiebreaker
+	ID          string         `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Category    string         `json:"category"`
+	Currency    string         `json:"currency"`
+	PriceAmount int64          `json:"priceAmount"`
+	AccountID   string         `json:"accountId"`
+	Images      []ProductImage `json:"images"`
+	ArchivedAt  *time.Time     `json:"archivedAt"`
+	Rating      float64        `json:"rating"`
+	ReviewCount int            `json:"reviewCount"`
+
+	RegularPriceAmount int64                   `json:"regularPriceAmount"`
+	PriceSchedules     []priceScheduleDocument `json:"priceSchedules"`
+	NextPriceChangeAt  *time.Time              `json:"nextPriceChangeAt"`
+
+	Attributes []attributeDocument `json:"attributes"`
+
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt"`
+
+	Popularity *Popularity `json:"popularity,omitempty"`
+
+	Stock  *int64            `json:"stock,omitempty"`
+	Bundle []BundleComponent `json:"bundle,omitempty"`
+
+	Screening *Screening `json:"screening,omitempty"`
+
+	// Decimal prices of documents written before prices were stored in minor
+	// units. They are converted on read and dropped by ReindexCatalog.
+	LegacyPrice        float64 `json:"price,omitempty"`
+	LegacyRegularPrice float64 `json:"regularPrice,omitempty"`
+}
+
+type priceScheduleDocument struct {
+	ID          string    `json:"id"`
+	PriceAmount int64     `json:"priceAmount"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+
+	LegacyPrice float64 `json:"price,omitempty"`
+}
+
+func newProductDocument(p Product) ProductDocument {
+	doc := ProductDocument{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Category:    p.Category,
+		Currency:    p.RegularPrice.Currency,
+		PriceAmount: p.Price.Amount,
+		AccountID:   p.AccountID,
+		Images:      p.Images,
+		ArchivedAt:  p.ArchivedAt,
+		Rating:      p.Rating,
+		ReviewCount: p.ReviewCount,
+
+		RegularPriceAmount: p.RegularPrice.Amount,
+		NextPriceChangeAt:  p.NextPriceChangeAt,
+
+		Attributes: newAttributeDocuments(p.Attributes),
+
+		Status:    p.Status,
+		PublishAt: p.PublishAt,
+
+		Popularity: p.Popularity,
+
+		Stock:  p.Stock,
+		Bundle: p.Bundle,
+
+		Screening: p.Screening,
+	}
+	if p.IsBundle() {
+		// Derived on read
+		doc.Stock = nil
+	}
+	for _, schedule := range p.PriceSchedules {
+		doc.PriceSchedules = append(doc.PriceSchedules, priceScheduleDocument{
+			ID:          schedule.ID,
+			PriceAmount: schedule.Price.Amount,
+			StartsAt:    schedule.StartsAt,
+			EndsAt:      schedule.EndsAt,
+		})
+	}
+	return doc
+}
+
+func (d ProductDocument) toProduct(id string) Product {
+	if d.Currency == "" {
+		d.migrateLegacyPrices()
+	}
+
+	product := Product{
+		ID:          id,
+		Name:        d.Name,
+		Description: d.Description,
+		Category:    d.Category,
+		Price:       money.New(d.PriceAmount, d.Currency),
+		AccountID:   d.AccountID,
+		Images:      d.Images,
+		ArchivedAt:  d.ArchivedAt,
+		Rating:      d.Rating,
+		ReviewCount: d.ReviewCount,
+
+		RegularPrice:      money.New(d.RegularPriceAmount, d.Currency),
+		NextPriceChangeAt: d.NextPriceChangeAt,
+
+		Attributes: toProductAttributes(d.Attributes),
+
+		Status:    d.Status,
+		PublishAt: d.PublishAt,
+
+		Popularity: d.Popularity,
+
+		Stock:  d.Stock,
+		Bundle: d.Bundle,
+
+		Screening: d.Screening,
+	}
+	// Products were live as soon as they were created before listings had
+	// a status
+	if product.Status == "" {
+		product.Status = StatusPublished
+	}
+	for _, schedule := range d.PriceSchedules {
+		product.PriceSchedules = append(product.PriceSchedules, PriceSchedule{
+			ID:       schedule.ID,
+			Price:    money.New(schedule.PriceAmount, d.Currency),
+			StartsAt: schedule.StartsAt,
+			EndsAt:   schedule.EndsAt,
+		})
+	}
+	return product
+}
+
+// migrateLegacyPrices converts decimal prices to minor units of the default
+// currency, mirroring migrateDocumentScript
+func (d *ProductDocument) migrateLegacyPrices() {
+	d.Currency = money.DefaultCurrency
+	d.PriceAmount = money.FromFloat(d.LegacyPrice, d.Currency).Amount
+	d.RegularPriceAmount = money.FromFloat(d.LegacyRegularPrice, d.Currency).Amount
+
+	// Documents written before price schedules only have the current price
+	if d.LegacyRegularPrice == 0 && len(d.PriceSchedules) == 0 {
+		d.RegularPriceAmount = d.PriceAmount
+	}
+
+	for i, schedule := range d.PriceSchedules {
+		d.PriceSchedules[i].PriceAmount = money.FromFloat(schedule.LegacyPrice, d.Currency).Amount
+	}
+}
+
+// formatVersion encodes the sequence number and primary term of a document
+// into the opaque version string handed to clients
+func formatVersion(seqNo, primaryTerm int64) string {
+	return fmt.Sprintf("%d_%d", seqNo, primaryTerm)
+}
+
+func parseVersion(version string) (seqNo, primaryTerm int64, err error) {
+	if _, err := fmt.Sscanf(version, "%d_%d", &seqNo, &primaryTerm); err != nil {
+		return 0, 0, ErrInvalidVersion
+	}
+	return seqNo, primaryTerm, nil
+}
+
+// hitVersion reads the version of a document returned by the get, mget or
+// search APIs
+func (r *elasticRepository) hitVersion(hit map[string]interface{}) string {
+	if r.externalVersions {
+		version, ok := hit["_version"].(float64)
+		if !ok {
+			return ""
+		}
+		return strconv.FormatInt(int64(version), 10)
+	}
+
+	seqNo, ok := hit["_seq_no"].(float64)
+	if !ok {
+		return ""
+	}
+	primaryTerm, _ := hit["_primary_term"].(float64)
+	return formatVersion(int64(seqNo), int64(primaryTerm))
 }
 
 func NewElasticRepository(url string) (Repository, error) {
+	return newElasticRepository(url)
+}
+
+func newElasticRepository(url string) (*elasticRepository, error) {
 	client, err := elasticsearch.NewClient(elasticsearch.Config{
 		Addresses: []string{url},
 	})
@@ -45,7 +269,27 @@ func NewElasticRepository(url string) (Repository, error) {
 		return nil, err
 	}
 
-	return &elasticRepository{client: client}, nil
+	r := &elasticRepository{client: client, firstPagePITs: make(chan struct{}, maxFirstPagePITs)}
+	if err := r.ensureCatalogIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := r.ensureReviewIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := r.ensurePriceHistoryIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := r.ensureExchangeRatesIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := r.ensureCategorySchemasIndex(context.Background()); err != nil {
+		return nil, err
+	}
+	if err := r.ensureScreeningRulesIndex(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return r, nil
 }
 
 func (r *elasticRepository) Close() {
@@ -53,31 +297,35 @@ func (r *elasticRepository) Close() {
 	// The underlying HTTP client will be garbage collected
 }
 
-func (r *elasticRepository) PutProduct(ctx context.Context, p Product) error {
-	doc := ProductDocument{
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		AccountID:   p.AccountID,
+func (r *elasticRepository) PutProduct(ctx context.Context, p Product, events ...Event) (string, error) {
+	if len(events) > 0 {
+		return "", ErrNoOutbox
 	}
 
+	doc := newProductDocument(p)
+
 	docBytes, err := json.Marshal(doc)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = r.client.Index(
-		"catalog",
+	res, err := r.client.Index(
+		catalogAlias,
 		bytes.NewReader(docBytes),
 		r.client.Index.WithDocumentID(p.ID),
 		r.client.Index.WithContext(ctx),
 	)
-	return err
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	return decodeWriteVersion(res)
 }
 
 func (r *elasticRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
 	res, err := r.client.Get(
-		"catalog",
+		catalogAlias,
 		id,
 		r.client.Get.WithContext(ctx),
 	)
@@ -115,92 +363,30 @@ func (r *elasticRepository) GetProductById(ctx context.Context, id string) (*Pro
 		return nil, err
 	}
 
-	return &Product{
-		ID:          id,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		AccountID:   product.AccountID,
-	}, nil
+	p := product.toProduct(id)
+	p.Version = r.hitVersion(result)
+	return &p, nil
 }
 
 func (r *elasticRepository) ListProducts(ctx context.Context, skip, take uint64) ([]Product, error) {
 	query := map[string]interface{}{
-		"query": map[string]interface{}{
+		"query": onlyPublished(map[string]interface{}{
 			"match_all": map[string]interface{}{},
-		},
-		"from": skip,
-		"size": take,
-	}
-
-	queryBytes, err := json.Marshal(query)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := r.client.Search(
-		r.client.Search.WithIndex("catalog"),
-		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
-		r.client.Search.WithContext(ctx),
-	)
-	if err != nil {
-		log.Println(err)
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
-	}
-
-	hits, ok := result["hits"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid hits format")
+		}),
+		"from":                skip,
+		"size":                take,
+		"seq_no_primary_term": true,
+		"version":             true,
 	}
 
-	hitsArray, ok := hits["hits"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("invalid hits array format")
-	}
-
-	var products []Product
-	for _, hit := range hitsArray {
-		hitMap, ok := hit.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		id, _ := hitMap["_id"].(string)
-		source, ok := hitMap["_source"].(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		sourceBytes, err := json.Marshal(source)
-		if err != nil {
-			continue
-		}
-
-		var product ProductDocument
-		if err := json.Unmarshal(sourceBytes, &product); err == nil {
-			products = append(products, Product{
-				ID:          id,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				AccountID:   product.AccountID,
-			})
-		}
-	}
-	return products, nil
+	return r.searchCatalog(ctx, query)
 }
 
 func (r *elasticRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]Product, error) {
 	docs := make([]map[string]interface{}, len(ids))
 	for i, id := range ids {
 		docs[i] = map[string]interface{}{
-			"_index": "catalog",
+			"_index": catalogAlias,
 			"_id":    id,
 		}
 	}
@@ -259,37 +445,37 @@ func (r *elasticRepository) ListProductsWithIds(ctx context.Context, ids []strin
 
 		var product ProductDocument
 		if err := json.Unmarshal(sourceBytes, &product); err == nil {
-			products = append(products, Product{
-				ID:          id,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				AccountID:   product.AccountID,
-			})
+			p := product.toProduct(id)
+			p.Version = r.hitVersion(docMap)
+			products = append(products, p)
 		}
 	}
 	return products, nil
 }
 
-func (r *elasticRepository) SearchProducts(ctx context.Context, query string, skip, take uint64) ([]Product, error) {
-	searchQuery := map[string]interface{}{
-		"query": map[string]interface{}{
-			"multi_match": map[string]interface{}{
-				"query":  query,
-				"fields": []string{"name", "description"},
-			},
-		},
-		"from": skip,
-		"size": take,
-	}
+// SearchProducts matches query against names and descriptions, or every
+// product if query is empty, keeping only products rated at least minRating
+// that match every attribute filter
+func (r *elasticRepository) SearchProducts(ctx context.Context, query string, skip, take uint64, minRating float64, sort string, attributes []AttributeFilter) ([]Product, error) {
+	searchQuery := productSearch(query, minRating, sort, attributes)
+	searchQuery["from"] = skip
+	searchQuery["size"] = take
+	searchQuery["seq_no_primary_term"] = true
+	searchQuery["version"] = true
+
+	return r.searchCatalog(ctx, searchQuery)
+}
 
-	queryBytes, err := json.Marshal(searchQuery)
+// searchCatalog runs a search request against the catalog and returns the
+// matching products with their versions
+func (r *elasticRepository) searchCatalog(ctx context.Context, query map[string]interface{}) ([]Product, error) {
+	queryBytes, err := json.Marshal(query)
 	if err != nil {
 		return nil, err
 	}
 
 	res, err := r.client.Search(
-		r.client.Search.WithIndex("catalog"),
+		r.client.Search.WithIndex(catalogAlias),
 		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
 		r.client.Search.WithContext(ctx),
 	)
@@ -334,47 +520,152 @@ func (r *elasticRepository) SearchProducts(ctx context.Context, query string, sk
 
 		var product ProductDocument
 		if err := json.Unmarshal(sourceBytes, &product); err == nil {
-			products = append(products, Product{
-				ID:          id,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				AccountID:   product.AccountID,
-			})
+			p := product.toProduct(id)
+			p.Version = r.hitVersion(hitMap)
+			products = append(products, p)
 		}
 	}
 	return products, nil
 }
 
-func (r *elasticRepository) UpdateProduct(ctx context.Context, updatedProduct Product) error {
-	doc := map[string]interface{}{
-		"doc": ProductDocument{
-			Name:        updatedProduct.Name,
-			Description: updatedProduct.Description,
-			Price:       updatedProduct.Price,
-			AccountID:   updatedProduct.AccountID,
+// productSearch builds the query and sort of a product search, see
+// SearchProducts
+func productSearch(query string, minRating float64, sort string, attributes []AttributeFilter) map[string]interface{} {
+	boolQuery := map[string]interface{}{
+		"must_not": hiddenProducts(),
+	}
+	if query != "" {
+		boolQuery["should"] = []interface{}{
+			// Stemmed fields with search-time synonym expansion
+			map[string]interface{}{
+				"multi_match": map[string]interface{}{
+					"query":  query,
+					"fields": []string{"name.stemmed^3", "description.stemmed"},
+				},
+			},
+			// Raw fields with typo tolerance
+			map[string]interface{}{
+				"multi_match": map[string]interface{}{
+					"query":         query,
+					"fields":        []string{"name^2", "description"},
+					"fuzziness":     "AUTO",
+					"prefix_length": 1,
+				},
+			},
+		}
+		boolQuery["minimum_should_match"] = 1
+	}
+	filters := attributeQueries(attributes)
+	if minRating > 0 {
+		filters = append(filters, map[string]interface{}{
+			"range": map[string]interface{}{
+				"rating": map[string]interface{}{"gte": minRating},
+			},
+		})
+	}
+	if len(filters) > 0 {
+		boolQuery["filter"] = filters
+	}
+
+	searchQuery := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": boolQuery,
 		},
 	}
+	switch sort {
+	case SortRelevance:
+		searchQuery["query"] = popularityBoost(searchQuery["query"].(map[string]interface{}), time.Now())
+	case SortRating:
+		searchQuery["sort"] = []interface{}{
+			map[string]interface{}{"rating": "desc"},
+			map[string]interface{}{"reviewCount": "desc"},
+			"_score",
+		}
+	case SortBestSelling:
+		searchQuery["sort"] = bestSellingSort()
+	}
+	return searchQuery
+}
+
+// UpdateProduct replaces the stored document. When updatedProduct carries a
+// version the write only succeeds if the document has not changed since that
+// version was read.
+func (r *elasticRepository) UpdateProduct(ctx context.Context, updatedProduct Product, events ...Event) (string, error) {
+	if len(events) > 0 {
+		return "", ErrNoOutbox
+	}
+
+	doc := map[string]interface{}{
+		"doc": newProductDocument(updatedProduct),
+	}
 
 	docBytes, err := json.Marshal(doc)
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	opts := []func(*esapi.UpdateRequest){
+		r.client.Update.WithContext(ctx),
+	}
+	if updatedProduct.Version != "" {
+		seqNo, primaryTerm, err := parseVersion(updatedProduct.Version)
+		if err != nil {
+			return "", err
+		}
+		opts = append(opts,
+			r.client.Update.WithIfSeqNo(int(seqNo)),
+			r.client.Update.WithIfPrimaryTerm(int(primaryTerm)),
+		)
 	}
 
-	_, err = r.client.Update(
-		"catalog",
+	res, err := r.client.Update(
+		catalogAlias,
 		updatedProduct.ID,
 		bytes.NewReader(docBytes),
-		r.client.Update.WithContext(ctx),
+		opts...,
 	)
-	return err
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case 404:
+		return "", ErrNotFound
+	case 409:
+		return "", ErrVersionConflict
+	}
+	return decodeWriteVersion(res)
+}
+
+func decodeWriteVersion(res *esapi.Response) (string, error) {
+	if res.IsError() {
+		return "", fmt.Errorf("write request failed: %s", res.String())
+	}
+
+	var result struct {
+		SeqNo       int64 `json:"_seq_no"`
+		PrimaryTerm int64 `json:"_primary_term"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return formatVersion(result.SeqNo, result.PrimaryTerm), nil
 }
 
 func (r *elasticRepository) DeleteProduct(ctx context.Context, productId string) error {
-	_, err := r.client.Delete(
-		"catalog",
+	res, err := r.client.Delete(
+		catalogAlias,
 		productId,
 		r.client.Delete.WithContext(ctx),
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("failed to delete product %s: %s", productId, res.String())
+	}
+	return nil
 }
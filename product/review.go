@@ -0,0 +1,582 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+
+	reviewIndex          = "reviews"
+	maxReviewTitleLength = 200
+	maxReviewBodyLength  = 5000
+)
+
+var (
+	ErrInvalidRating       = errors.New("rating must be between 1 and 5")
+	ErrInvalidReview       = errors.New("review title and body are required")
+	ErrReviewExists        = errors.New("account has already reviewed this product")
+	ErrOwnReview           = errors.New("sellers cannot review or vote on their own content")
+	ErrInvalidReviewStatus = errors.New("invalid review status")
+)
+
+var reviewMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"productId":        map[string]interface{}{"type": "keyword"},
+		"accountId":        map[string]interface{}{"type": "keyword"},
+		"rating":           map[string]interface{}{"type": "byte"},
+		"title":            map[string]interface{}{"type": "text"},
+		"body":             map[string]interface{}{"type": "text"},
+		"verifiedPurchase": map[string]interface{}{"type": "boolean"},
+		"status":           map[string]interface{}{"type": "keyword"},
+		"reply": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"body":      map[string]interface{}{"type": "text"},
+				"createdAt": map[string]interface{}{"type": "date"},
+			},
+		},
+		"helpfulVoters": map[string]interface{}{"type": "keyword"},
+		"helpfulVotes":  map[string]interface{}{"type": "integer"},
+		"createdAt":     map[string]interface{}{"type": "date"},
+	},
+}
+
+type SellerReply struct {
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Review struct {
+	ID               string       `json:"-"`
+	ProductID        string       `json:"productId"`
+	AccountID        string       `json:"accountId"`
+	Rating           int          `json:"rating"`
+	Title            string       `json:"title"`
+	Body             string       `json:"body"`
+	VerifiedPurchase bool         `json:"verifiedPurchase"`
+	Status           string       `json:"status"`
+	Reply            *SellerReply `json:"reply"`
+	HelpfulVoters    []string     `json:"helpfulVoters"`
+	HelpfulVotes     int          `json:"helpfulVotes"`
+	CreatedAt        time.Time    `json:"createdAt"`
+	Version          string       `json:"-"`
+}
+
+// reviewID is derived from the product and author so each account can only
+// review a product once
+func reviewID(productId, accountId string) string {
+	return productId + "_" + accountId
+}
+
+// PostReview records a review by accountId. verifiedPurchase is determined by
+// the caller, which has access to the account's order history, and is not
+// checked: the product service trusts its callers like it trusts accountId.
+func (p productService) PostReview(ctx context.Context, productId, accountId string, rating int, title, body string, verifiedPurchase bool) (*Review, error) {
+	if rating < 1 || rating > 5 {
+		return nil, ErrInvalidRating
+	}
+
+	title, body = strings.TrimSpace(title), strings.TrimSpace(body)
+	if title == "" || body == "" || len(title) > maxReviewTitleLength || len(body) > maxReviewBodyLength {
+		return nil, ErrInvalidReview
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.ArchivedAt != nil {
+		return nil, ErrNotFound
+	}
+	if product.AccountID == accountId {
+		return nil, ErrOwnReview
+	}
+
+	review := Review{
+		ID:               reviewID(productId, accountId),
+		ProductID:        productId,
+		AccountID:        accountId,
+		Rating:           rating,
+		Title:            title,
+		Body:             body,
+		VerifiedPurchase: verifiedPurchase,
+		Status:           ReviewApproved,
+		HelpfulVoters:    []string{},
+		CreatedAt:        time.Now().UTC(),
+	}
+
+	review.Version, err = p.repo.CreateReview(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.repo.RefreshProductRating(ctx, productId); err != nil {
+		log.Printf("Error refreshing rating for product %s: %v", productId, err)
+	}
+
+	return &review, nil
+}
+
+func (p productService) GetProductReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error) {
+	return p.repo.ListReviews(ctx, productId, skip, take)
+}
+
+// ReplyToReview sets the seller's public reply, replacing any earlier one
+func (p productService) ReplyToReview(ctx context.Context, reviewId, accountId, body string) (*Review, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || len(body) > maxReviewBodyLength {
+		return nil, ErrInvalidReview
+	}
+
+	review, err := p.repo.GetReview(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := p.repo.GetProductById(ctx, review.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.AccountID != accountId {
+		return nil, ErrUnauthorized
+	}
+
+	review.Reply = &SellerReply{Body: body, CreatedAt: time.Now().UTC()}
+	review.Version, err = p.repo.UpdateReview(ctx, *review)
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// VoteReviewHelpful counts accountId's helpful vote once per review
+func (p productService) VoteReviewHelpful(ctx context.Context, reviewId, accountId string) (*Review, error) {
+	review, err := p.repo.GetReview(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+
+	if review.AccountID == accountId {
+		return nil, ErrOwnReview
+	}
+	if slices.Contains(review.HelpfulVoters, accountId) {
+		return review, nil
+	}
+
+	review.HelpfulVoters = append(review.HelpfulVoters, accountId)
+	review.HelpfulVotes = len(review.HelpfulVoters)
+	review.Version, err = p.repo.UpdateReview(ctx, *review)
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+// ModerateReview approves or rejects a review. Only approved reviews are
+// listed and count towards the product rating.
+func (p productService) ModerateReview(ctx context.Context, reviewId, status string) (*Review, error) {
+	if status != ReviewApproved && status != ReviewRejected {
+		return nil, ErrInvalidReviewStatus
+	}
+
+	review, err := p.repo.GetReview(ctx, reviewId)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == status {
+		return review, nil
+	}
+
+	review.Status = status
+	review.Version, err = p.repo.UpdateReview(ctx, *review)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.repo.RefreshProductRating(ctx, review.ProductID); err != nil {
+		log.Printf("Error refreshing rating for product %s: %v", review.ProductID, err)
+	}
+
+	return review, nil
+}
+
+func (r *elasticRepository) ensureReviewIndex(ctx context.Context) error {
+	return r.ensureIndex(ctx, reviewIndex, reviewMapping)
+}
+
+func (r *elasticRepository) CreateReview(ctx context.Context, review Review) (string, error) {
+	docBytes, err := json.Marshal(review)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := r.client.Create(
+		reviewIndex,
+		review.ID,
+		bytes.NewReader(docBytes),
+		r.client.Create.WithRefresh("wait_for"),
+		r.client.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 409 {
+		return "", ErrReviewExists
+	}
+	return decodeWriteVersion(res)
+}
+
+func (r *elasticRepository) GetReview(ctx context.Context, id string) (*Review, error) {
+	res, err := r.client.Get(
+		reviewIndex,
+		id,
+		r.client.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get review %s: %s", id, res.String())
+	}
+
+	var result struct {
+		ID          string `json:"_id"`
+		SeqNo       int64  `json:"_seq_no"`
+		PrimaryTerm int64  `json:"_primary_term"`
+		Source      Review `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	review := result.Source
+	review.ID = result.ID
+	review.Version = formatVersion(result.SeqNo, result.PrimaryTerm)
+	return &review, nil
+}
+
+// UpdateReview replaces the stored review if it is still at review.Version
+func (r *elasticRepository) UpdateReview(ctx context.Context, review Review) (string, error) {
+	seqNo, primaryTerm, err := parseVersion(review.Version)
+	if err != nil {
+		return "", err
+	}
+
+	docBytes, err := json.Marshal(review)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := r.client.Index(
+		reviewIndex,
+		bytes.NewReader(docBytes),
+		r.client.Index.WithDocumentID(review.ID),
+		r.client.Index.WithIfSeqNo(int(seqNo)),
+		r.client.Index.WithIfPrimaryTerm(int(primaryTerm)),
+		r.client.Index.WithRefresh("wait_for"),
+		r.client.Index.WithContext(ctx),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 409 {
+		return "", ErrVersionConflict
+	}
+	return decodeWriteVersion(res)
+}
+
+func (r *elasticRepository) ListReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"productId": productId}},
+					map[string]interface{}{"term": map[string]interface{}{"status": ReviewApproved}},
+				},
+			},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"helpfulVotes": "desc"},
+			map[string]interface{}{"createdAt": "desc"},
+		},
+		"from":                skip,
+		"size":                take,
+		"seq_no_primary_term": true,
+	}
+
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(reviewIndex),
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("review search failed: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				ID          string `json:"_id"`
+				SeqNo       int64  `json:"_seq_no"`
+				PrimaryTerm int64  `json:"_primary_term"`
+				Source      Review `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	reviews := []Review{}
+	for _, hit := range result.Hits.Hits {
+		review := hit.Source
+		review.ID = hit.ID
+		review.Version = formatVersion(hit.SeqNo, hit.PrimaryTerm)
+		reviews = append(reviews, review)
+	}
+	return reviews, nil
+}
+
+// RefreshProductRating recomputes the average rating and review count from
+// the approved reviews and stores them on the product document
+func (r *elasticRepository) RefreshProductRating(ctx context.Context, productId string) error {
+	rating, count, err := r.productRating(ctx, productId)
+	if err != nil {
+		return err
+	}
+
+	docBytes, err := json.Marshal(map[string]interface{}{
+		"doc": map[string]interface{}{
+			"rating":      rating,
+			"reviewCount": count,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	updateRes, err := r.client.Update(
+		catalogAlias,
+		productId,
+		bytes.NewReader(docBytes),
+		r.client.Update.WithRetryOnConflict(3),
+		r.client.Update.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer updateRes.Body.Close()
+
+	if updateRes.IsError() {
+		return fmt.Errorf("failed to update rating for product %s: %s", productId, updateRes.String())
+	}
+	return nil
+}
+
+// productRating returns the average rating and number of approved reviews of
+// a product
+func (r *elasticRepository) productRating(ctx context.Context, productId string) (float64, int, error) {
+	query := map[string]interface{}{
+		"size": 0,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"productId": productId}},
+					map[string]interface{}{"term": map[string]interface{}{"status": ReviewApproved}},
+				},
+			},
+		},
+		"aggs": map[string]interface{}{
+			"rating": map[string]interface{}{
+				"stats": map[string]interface{}{"field": "rating"},
+			},
+		},
+	}
+
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(reviewIndex),
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, 0, fmt.Errorf("rating aggregation failed: %s", res.String())
+	}
+
+	var result struct {
+		Aggregations struct {
+			Rating struct {
+				Count int      `json:"count"`
+				Avg   *float64 `json:"avg"`
+			} `json:"rating"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, 0, err
+	}
+
+	rating := 0.0
+	if result.Aggregations.Rating.Avg != nil {
+		rating = *result.Aggregations.Rating.Avg
+	}
+	return rating, result.Aggregations.Rating.Count, nil
+}
+
+const reviewColumns = `id, product_id, account_id, rating, title, body, verified_purchase, status, reply, helpful_voters, version, created_at`
+
+func (r *postgresRepository) CreateReview(ctx context.Context, review Review) (string, error) {
+	var version int64
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO product_reviews (id, product_id, account_id, rating, title, body, verified_purchase, status, reply, helpful_voters, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10::text[], '{}'), $11)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING version
+	`, review.ID, review.ProductID, review.AccountID, review.Rating, review.Title, review.Body,
+		review.VerifiedPurchase, review.Status, review.Reply, review.HelpfulVoters, review.CreatedAt).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrReviewExists
+	}
+	if err != nil {
+		return "", err
+	}
+	return formatProductVersion(version), nil
+}
+
+func (r *postgresRepository) GetReview(ctx context.Context, id string) (*Review, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+reviewColumns+` FROM product_reviews WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := pgx.CollectRows(rows, scanReview)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, ErrNotFound
+	}
+	return &reviews[0], nil
+}
+
+// UpdateReview replaces the stored review if it is still at review.Version
+func (r *postgresRepository) UpdateReview(ctx context.Context, review Review) (string, error) {
+	expected, err := parseProductVersion(review.Version)
+	if err != nil {
+		return "", err
+	}
+
+	var version int64
+	err = r.db.QueryRow(ctx, `
+		UPDATE product_reviews SET
+			rating = $3,
+			title = $4,
+			body = $5,
+			verified_purchase = $6,
+			status = $7,
+			reply = $8,
+			helpful_voters = COALESCE($9::text[], '{}'),
+			version = version + 1
+		WHERE id = $1 AND version = $2
+		RETURNING version
+	`, review.ID, expected, review.Rating, review.Title, review.Body, review.VerifiedPurchase,
+		review.Status, review.Reply, review.HelpfulVoters).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		var exists bool
+		if err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM product_reviews WHERE id = $1)`, review.ID).Scan(&exists); err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", ErrNotFound
+		}
+		return "", ErrVersionConflict
+	}
+	if err != nil {
+		return "", err
+	}
+	return formatProductVersion(version), nil
+}
+
+func (r *postgresRepository) ListReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reviewColumns+`
+		FROM product_reviews
+		WHERE product_id = $1 AND status = $2
+		ORDER BY cardinality(helpful_voters) DESC, created_at DESC, id
+		OFFSET $3 LIMIT $4
+	`, productId, ReviewApproved, skip, take)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := pgx.CollectRows(rows, scanReview)
+	if err != nil {
+		return nil, err
+	}
+	if reviews == nil {
+		reviews = []Review{}
+	}
+	return reviews, nil
+}
+
+// productRating returns the average rating and number of approved reviews of
+// a product
+func (r *postgresRepository) productRating(ctx context.Context, productId string) (float64, int, error) {
+	var rating float64
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(AVG(rating), 0)::float8, COUNT(*)
+		FROM product_reviews
+		WHERE product_id = $1 AND status = $2
+	`, productId, ReviewApproved).Scan(&rating, &count)
+	return rating, count, err
+}
+
+func scanReview(row pgx.CollectableRow) (Review, error) {
+	var review Review
+	var version int64
+	err := row.Scan(&review.ID, &review.ProductID, &review.AccountID, &review.Rating, &review.Title, &review.Body,
+		&review.VerifiedPurchase, &review.Status, &review.Reply, &review.HelpfulVoters, &version, &review.CreatedAt)
+	review.HelpfulVotes = len(review.HelpfulVoters)
+	review.Version = formatProductVersion(version)
+	return review, err
+}
@@ -0,0 +1,424 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+)
+
+const (
+	screeningRulesIndex = "screening_rules"
+	screeningRulesID    = "current"
+
+	maxBannedTerms = 500
+
+	// maxDuplicates is how many matching listings a duplicate reason names
+	maxDuplicates = 3
+)
+
+// Screening statuses. Flagged and rejected products are hidden from
+// shoppers whatever their listing status.
+const (
+	ScreeningFlagged  = "flagged"
+	ScreeningApproved = "approved"
+	ScreeningRejected = "rejected"
+)
+
+var (
+	ErrInvalidScreeningRules = errors.New("screening rules need distinct banned terms and non-negative price bounds in one currency, with the minimum below the maximum")
+	ErrNotFlagged            = errors.New("product is not waiting for moderation")
+)
+
+// ScreeningRules are checked whenever a product is created, updated or
+// imported. Zero price bounds are not checked.
+type ScreeningRules struct {
+	// BannedTerms flag products whose name or description contains one of
+	// them, ignoring case
+	BannedTerms []string    `json:"bannedTerms"`
+	MinPrice    money.Money `json:"minPrice"`
+	MaxPrice    money.Money `json:"maxPrice"`
+	// DetectDuplicates flags products whose name and description closely
+	// match a published listing
+	DetectDuplicates bool      `json:"detectDuplicates"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// Screening is why a product was held for moderation and what a moderator
+// decided. Products that pass screening have none.
+type Screening struct {
+	Status    string    `json:"status"`
+	Reasons   []string  `json:"reasons"`
+	FlaggedAt time.Time `json:"flaggedAt"`
+	// Note is the moderator's explanation of a rejection
+	Note       string     `json:"note,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+}
+
+// Held reports whether the product waits for a moderator or was rejected
+func (p Product) Held() bool {
+	return p.Screening != nil && p.Screening.Status != ScreeningApproved
+}
+
+var screeningRulesMapping = map[string]interface{}{
+	"dynamic": "strict",
+	"properties": map[string]interface{}{
+		"bannedTerms": map[string]interface{}{"type": "keyword", "index": false},
+		"minPrice": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"maxPrice": map[string]interface{}{
+			"type":    "object",
+			"enabled": false,
+		},
+		"detectDuplicates": map[string]interface{}{"type": "boolean"},
+		"updatedAt":        map[string]interface{}{"type": "date"},
+	},
+}
+
+// Validate lowercases and trims banned terms and normalizes the currency of
+// the price bounds
+func (r ScreeningRules) Validate() (ScreeningRules, error) {
+	if len(r.BannedTerms) > maxBannedTerms {
+		return ScreeningRules{}, ErrInvalidScreeningRules
+	}
+
+	terms := []string{}
+	for _, term := range r.BannedTerms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term == "" || slices.Contains(terms, term) {
+			return ScreeningRules{}, ErrInvalidScreeningRules
+		}
+		terms = append(terms, term)
+	}
+	r.BannedTerms = terms
+
+	var err error
+	for _, bound := range []*money.Money{&r.MinPrice, &r.MaxPrice} {
+		if bound.IsZero() {
+			*bound = money.Money{}
+			continue
+		}
+		if *bound, err = bound.Validate(); err != nil {
+			return ScreeningRules{}, ErrInvalidScreeningRules
+		}
+	}
+	if !r.MinPrice.IsZero() && !r.MaxPrice.IsZero() {
+		if r.MinPrice.Currency != r.MaxPrice.Currency || r.MaxPrice.Less(r.MinPrice) {
+			return ScreeningRules{}, ErrInvalidScreeningRules
+		}
+	}
+	return r, nil
+}
+
+func (p productService) GetScreeningRules(ctx context.Context) (*ScreeningRules, error) {
+	rules, err := p.repo.GetScreeningRules(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return &ScreeningRules{BannedTerms: []string{}}, nil
+	}
+	return rules, err
+}
+
+// UpdateScreeningRules replaces the rules products are screened against.
+// Products already stored are screened again on their next update.
+func (p productService) UpdateScreeningRules(ctx context.Context, rules ScreeningRules) (*ScreeningRules, error) {
+	rules, err := rules.Validate()
+	if err != nil {
+		return nil, err
+	}
+	rules.UpdatedAt = time.Now().UTC()
+
+	if err := p.repo.PutScreeningRules(ctx, rules); err != nil {
+		return nil, err
+	}
+
+	log.Printf("UpdateScreeningRules: %d banned terms, duplicate detection %t", len(rules.BannedTerms), rules.DetectDuplicates)
+	return &rules, nil
+}
+
+// screeningRules returns nil when no rules have been set
+func (p productService) screeningRules(ctx context.Context) (*ScreeningRules, error) {
+	rules, err := p.repo.GetScreeningRules(ctx)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	return rules, err
+}
+
+// screen checks product against rules and returns its new screening, nil
+// when it passes. A product a moderator approved stays approved unless it
+// breaks a rule it was not approved for.
+func (p productService) screen(ctx context.Context, rules *ScreeningRules, product Product) (*Screening, error) {
+	if rules == nil {
+		return nil, nil
+	}
+
+	reasons := rules.check(product, p.exchangeRatesOrNil(ctx))
+	if rules.DetectDuplicates {
+		duplicates, err := p.repo.FindDuplicateProducts(ctx, product, maxDuplicates)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range duplicates {
+			reasons = append(reasons, fmt.Sprintf("duplicates listing %s", id))
+		}
+	}
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+
+	previous := product.Screening
+	if previous != nil && previous.Status == ScreeningApproved {
+		approved := true
+		for _, reason := range reasons {
+			if !slices.Contains(previous.Reasons, reason) {
+				approved = false
+				break
+			}
+		}
+		if approved {
+			return previous, nil
+		}
+	}
+
+	return &Screening{
+		Status:    ScreeningFlagged,
+		Reasons:   reasons,
+		FlaggedAt: time.Now().UTC(),
+	}, nil
+}
+
+// check returns the reasons product breaks the banned term and price rules.
+// Prices in another currency than the bounds are converted with rates, and
+// are not checked when they cannot be.
+func (r ScreeningRules) check(product Product, rates *money.ExchangeRates) []string {
+	var reasons []string
+
+	text := strings.ToLower(product.Name + "\n" + product.Description)
+	for _, term := range r.BannedTerms {
+		if strings.Contains(text, term) {
+			reasons = append(reasons, fmt.Sprintf("contains banned term %q", term))
+		}
+	}
+
+	bounds := r.MinPrice
+	if bounds.IsZero() {
+		bounds = r.MaxPrice
+	}
+	if bounds.IsZero() {
+		return reasons
+	}
+
+	price := product.RegularPrice
+	if price.Currency != bounds.Currency {
+		if rates == nil {
+			return reasons
+		}
+		converted, _, err := rates.Convert(price, bounds.Currency)
+		if err != nil {
+			log.Printf("Screening %s: cannot check price: %v", product.ID, err)
+			return reasons
+		}
+		price = converted
+	}
+
+	if !r.MinPrice.IsZero() && price.Less(r.MinPrice) {
+		reasons = append(reasons, fmt.Sprintf("price %s is below the minimum of %s", product.RegularPrice, r.MinPrice))
+	}
+	if !r.MaxPrice.IsZero() && r.MaxPrice.Less(price) {
+		reasons = append(reasons, fmt.Sprintf("price %s is above the maximum of %s", product.RegularPrice, r.MaxPrice))
+	}
+	return reasons
+}
+
+func (p productService) exchangeRatesOrNil(ctx context.Context) *money.ExchangeRates {
+	rates, err := p.repo.GetExchangeRates(ctx)
+	if err != nil {
+		return nil
+	}
+	return rates
+}
+
+// ListFlaggedProducts returns the products waiting for moderation, longest
+// waiting first
+func (p productService) ListFlaggedProducts(ctx context.Context, skip, take uint64) ([]Product, error) {
+	return p.repo.ListFlaggedProducts(ctx, skip, take)
+}
+
+// ApproveProduct releases a flagged product. It is listed again if its
+// seller has published it.
+func (p productService) ApproveProduct(ctx context.Context, productId string) (*Product, error) {
+	return p.moderateProduct(ctx, productId, ScreeningApproved, "")
+}
+
+// RejectProduct keeps a flagged product hidden and tells its seller why.
+// Updating the product screens it again.
+func (p productService) RejectProduct(ctx context.Context, productId, note string) (*Product, error) {
+	return p.moderateProduct(ctx, productId, ScreeningRejected, strings.TrimSpace(note))
+}
+
+func (p productService) moderateProduct(ctx context.Context, productId, status, note string) (*Product, error) {
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if product.Screening == nil || product.Screening.Status != ScreeningFlagged {
+		return nil, ErrNotFlagged
+	}
+
+	now := time.Now().UTC()
+	screening := *product.Screening
+	screening.Status = status
+	screening.Note = note
+	screening.ReviewedAt = &now
+	product.Screening = &screening
+
+	product.Version, err = p.repo.UpdateProduct(ctx, *product)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("moderateProduct: %s is %s", product.ID, status)
+	return product, nil
+}
+
+// FindDuplicateProducts returns up to size published products of any seller
+// whose name matches every term of the product's name and whose
+// description closely matches its description
+func (r *elasticRepository) FindDuplicateProducts(ctx context.Context, p Product, size int) ([]string, error) {
+	must := []interface{}{
+		map[string]interface{}{
+			"match": map[string]interface{}{
+				"name": map[string]interface{}{"query": p.Name, "operator": "and"},
+			},
+		},
+	}
+	if strings.TrimSpace(p.Description) != "" {
+		must = append(must, map[string]interface{}{
+			"match": map[string]interface{}{
+				"description": map[string]interface{}{"query": p.Description, "minimum_should_match": "80%"},
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"query": onlyPublished(map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": must,
+				"must_not": map[string]interface{}{
+					"ids": map[string]interface{}{"values": []string{p.ID}},
+				},
+			},
+		}),
+		"size":    size,
+		"_source": []string{"accountId"},
+	}
+
+	products, err := r.searchCatalog(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	return ids, nil
+}
+
+func (r *elasticRepository) ListFlaggedProducts(ctx context.Context, skip, take uint64) ([]Product, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"term": map[string]interface{}{"screening.status": ScreeningFlagged}},
+				},
+				"must_not": []interface{}{
+					map[string]interface{}{"exists": map[string]interface{}{"field": "archivedAt"}},
+				},
+			},
+		},
+		"sort": []interface{}{
+			map[string]interface{}{"screening.flaggedAt": "asc"},
+		},
+		"from":                skip,
+		"size":                take,
+		"seq_no_primary_term": true,
+		"version":             true,
+	}
+
+	return r.searchCatalog(ctx, query)
+}
+
+func (r *elasticRepository) ensureScreeningRulesIndex(ctx context.Context) error {
+	return r.ensureIndex(ctx, screeningRulesIndex, screeningRulesMapping)
+}
+
+func (r *elasticRepository) GetScreeningRules(ctx context.Context) (*ScreeningRules, error) {
+	res, err := r.client.Get(
+		screeningRulesIndex,
+		screeningRulesID,
+		r.client.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == 404 {
+		return nil, ErrNotFound
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("failed to get screening rules: %s", res.String())
+	}
+
+	var result struct {
+		Source ScreeningRules `json:"_source"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result.Source, nil
+}
+
+func (r *elasticRepository) PutScreeningRules(ctx context.Context, rules ScreeningRules) error {
+	docBytes, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.client.Index(
+		screeningRulesIndex,
+		bytes.NewReader(docBytes),
+		r.client.Index.WithDocumentID(screeningRulesID),
+		r.client.Index.WithContext(ctx),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to store screening rules: %s", res.String())
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetScreeningRules(ctx context.Context) (*ScreeningRules, error) {
+	var rules ScreeningRules
+	if err := r.getSetting(ctx, screeningRulesSetting, &rules); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+func (r *postgresRepository) PutScreeningRules(ctx context.Context, rules ScreeningRules) error {
+	return r.putSetting(ctx, screeningRulesSetting, rules)
+}
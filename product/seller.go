@@ -0,0 +1,90 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+var (
+	ErrInvalidStatus = errors.New("invalid status, expected draft, pending_review, published or unpublished")
+)
+
+var productStatuses = []string{StatusDraft, StatusPendingReview, StatusPublished, StatusUnpublished}
+
+// ListProductsByAccount lists the products of a seller whatever their status,
+// optionally only those in statuses. archived selects only archived or only
+// active products when set.
+func (p productService) ListProductsByAccount(ctx context.Context, accountId string, statuses []string, archived *bool, skip, take uint64) ([]Product, error) {
+	for _, status := range statuses {
+		if !slices.Contains(productStatuses, status) {
+			return nil, ErrInvalidStatus
+		}
+	}
+	if take == 0 {
+		take = defaultPageSize
+	}
+	if take > maxPageSize {
+		take = maxPageSize
+	}
+	products, err := p.repo.ListProductsByAccount(ctx, accountId, statuses, archived, skip, take)
+	if err != nil {
+		return nil, err
+	}
+	return p.deriveBundleStock(ctx, products)
+}
+
+func (r *elasticRepository) ListProductsByAccount(ctx context.Context, accountId string, statuses []string, archived *bool, skip, take uint64) ([]Product, error) {
+	filters := []interface{}{
+		map[string]interface{}{"term": map[string]interface{}{"accountId": accountId}},
+	}
+
+	if len(statuses) > 0 {
+		matches := []interface{}{
+			map[string]interface{}{"terms": map[string]interface{}{"status": statuses}},
+		}
+		// Products written before listings had a status are published
+		if slices.Contains(statuses, StatusPublished) {
+			matches = append(matches, map[string]interface{}{
+				"bool": map[string]interface{}{
+					"must_not": map[string]interface{}{
+						"exists": map[string]interface{}{"field": "status"},
+					},
+				},
+			})
+		}
+		filters = append(filters, map[string]interface{}{
+			"bool": map[string]interface{}{
+				"should":               matches,
+				"minimum_should_match": 1,
+			},
+		})
+	}
+
+	boolQuery := map[string]interface{}{}
+	archivedQuery := map[string]interface{}{
+		"exists": map[string]interface{}{"field": "archivedAt"},
+	}
+	if archived != nil && *archived {
+		filters = append(filters, archivedQuery)
+	} else if archived != nil {
+		boolQuery["must_not"] = archivedQuery
+	}
+	boolQuery["filter"] = filters
+
+	return r.searchCatalog(ctx, map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": boolQuery,
+		},
+		// Products with the same name are ordered by ID so pages do not
+		// overlap or skip any of them
+		"sort": []interface{}{
+			map[string]interface{}{"name.keyword": "asc"},
+			map[string]interface{}{"id": "asc"},
+		},
+		"from":                skip,
+		"size":                take,
+		"seq_no_primary_term": true,
+		"version":             true,
+	})
+}
@@ -1,14 +1,23 @@
 package product
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"time"
 
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"github.com/go-systems-lab/go-ecommerce-lld/product/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -23,40 +32,166 @@ func ListenGRPC(s Service, port int) error {
 		return err
 	}
 
-	srv := grpc.NewServer()
+	srv := grpc.NewServer(grpc.UnaryInterceptor(recordActor), grpc.StreamInterceptor(recordStreamActor))
 	pb.RegisterProductServiceServer(srv, &grpcServer{service: s, UnimplementedProductServiceServer: pb.UnimplementedProductServiceServer{}})
 	reflection.Register(srv)
 	return srv.Serve(lis)
 }
 
+// recordActor attributes the product writes of a request to the account it
+// is made for, see WithActor
+func recordActor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if r, ok := req.(interface{ GetAccountId() string }); ok && r.GetAccountId() != "" {
+		ctx = WithActor(ctx, r.GetAccountId())
+	}
+	return handler(ctx, req)
+}
+
+// recordStreamActor attributes the product writes of a client stream to the
+// account named in its first message, which carries the upload or import
+// options
+func recordStreamActor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &actorStream{ServerStream: ss, ctx: ss.Context()})
+}
+
+type actorStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received bool
+}
+
+func (s *actorStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *actorStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	// Only the first message names the account. Later messages may be
+	// received on another goroutine, so the context is not touched again.
+	if !s.received {
+		s.received = true
+		if accountId := streamAccountId(m); accountId != "" {
+			s.ctx = WithActor(s.ctx, accountId)
+		}
+	}
+	return nil
+}
+
+func streamAccountId(m interface{}) string {
+	switch r := m.(type) {
+	case *pb.UploadProductImageRequest:
+		return r.GetInfo().GetAccountId()
+	case *pb.ImportProductsRequest:
+		return r.GetOptions().GetAccountId()
+	case interface{ GetAccountId() string }:
+		return r.GetAccountId()
+	}
+	return ""
+}
+
+// productErrorDomain is the domain of the ErrorInfo that productStatus adds
+// to statuses
+const productErrorDomain = "product"
+
+// productErrors are the errors productStatus sends to clients, with their
+// status code and the ErrorInfo reason fromProductStatus maps back to the
+// error. An error that wraps several is sent as the first one listed.
+var productErrors = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{ErrImageNotFound, codes.NotFound, "IMAGE_NOT_FOUND"},
+	{ErrPriceScheduleMissing, codes.NotFound, "PRICE_SCHEDULE_MISSING"},
+	{ErrNoExchangeRates, codes.NotFound, "NO_EXCHANGE_RATES"},
+	{ErrNoCategorySchema, codes.NotFound, "NO_CATEGORY_SCHEMA"},
+
+	{ErrUnauthorized, codes.PermissionDenied, "UNAUTHORIZED"},
+	{ErrOwnReview, codes.PermissionDenied, "OWN_REVIEW"},
+
+	{ErrVersionConflict, codes.Aborted, "VERSION_CONFLICT"},
+
+	{ErrReviewExists, codes.AlreadyExists, "REVIEW_EXISTS"},
+
+	{ErrInvalidPrice, codes.InvalidArgument, "INVALID_PRICE"},
+	{ErrInvalidSchedule, codes.InvalidArgument, "INVALID_SCHEDULE"},
+	{money.ErrCurrencyMismatch, codes.InvalidArgument, "CURRENCY_MISMATCH"},
+	{money.ErrInvalidCurrency, codes.InvalidArgument, "INVALID_CURRENCY"},
+	{money.ErrInvalidRate, codes.InvalidArgument, "INVALID_RATE"},
+	{ErrInvalidVersion, codes.InvalidArgument, "INVALID_VERSION"},
+	{ErrInvalidUpdateMask, codes.InvalidArgument, "INVALID_UPDATE_MASK"},
+	{ErrInvalidStatus, codes.InvalidArgument, "INVALID_STATUS"},
+	{ErrInvalidSort, codes.InvalidArgument, "INVALID_SORT"},
+	{ErrInvalidCursor, codes.InvalidArgument, "INVALID_CURSOR"},
+	{ErrInvalidAttribute, codes.InvalidArgument, "INVALID_ATTRIBUTE"},
+	{ErrInvalidSchema, codes.InvalidArgument, "INVALID_SCHEMA"},
+	{ErrInvalidStock, codes.InvalidArgument, "INVALID_STOCK"},
+	{ErrBundleStock, codes.InvalidArgument, "BUNDLE_STOCK"},
+	{ErrInvalidBundle, codes.InvalidArgument, "INVALID_BUNDLE"},
+	{ErrBundleNested, codes.InvalidArgument, "BUNDLE_NESTED"},
+	{ErrInvalidScreeningRules, codes.InvalidArgument, "INVALID_SCREENING_RULES"},
+	{ErrInvalidSynonymRule, codes.InvalidArgument, "INVALID_SYNONYM_RULE"},
+	{ErrInvalidRating, codes.InvalidArgument, "INVALID_RATING"},
+	{ErrInvalidReview, codes.InvalidArgument, "INVALID_REVIEW"},
+	{ErrInvalidReviewStatus, codes.InvalidArgument, "INVALID_REVIEW_STATUS"},
+	{ErrUnsupportedImage, codes.InvalidArgument, "UNSUPPORTED_IMAGE"},
+	{ErrImageDimensions, codes.InvalidArgument, "IMAGE_DIMENSIONS"},
+	{ErrImageTooLarge, codes.InvalidArgument, "IMAGE_TOO_LARGE"},
+	{ErrInvalidImageOrder, codes.InvalidArgument, "INVALID_IMAGE_ORDER"},
+	{ErrUnsupportedFormat, codes.InvalidArgument, "UNSUPPORTED_FORMAT"},
+	{ErrMissingColumns, codes.InvalidArgument, "MISSING_COLUMNS"},
+	{ErrInvalidRetention, codes.InvalidArgument, "INVALID_RETENTION"},
+
+	{ErrInvalidStatusTransition, codes.FailedPrecondition, "INVALID_STATUS_TRANSITION"},
+	{ErrNotPublished, codes.FailedPrecondition, "NOT_PUBLISHED"},
+	{ErrNotFlagged, codes.FailedPrecondition, "NOT_FLAGGED"},
+	{ErrCursorExpired, codes.FailedPrecondition, "CURSOR_EXPIRED"},
+	{ErrTooManySchedules, codes.FailedPrecondition, "TOO_MANY_SCHEDULES"},
+	{ErrInsufficientStock, codes.FailedPrecondition, "INSUFFICIENT_STOCK"},
+	{ErrNoHistory, codes.FailedPrecondition, "NO_HISTORY"},
+	{ErrNoOutbox, codes.FailedPrecondition, "NO_OUTBOX"},
+}
+
+// productStatus maps product errors to status codes, so clients can tell
+// bad requests and conflicts from failures. The status names the error in an
+// ErrorInfo, so clients can tell apart errors sharing a code. Errors it does
+// not know are logged and returned as they are.
+func productStatus(err error) error {
+	for _, e := range productErrors {
+		if !errors.Is(err, e.err) {
+			continue
+		}
+		st, detailsErr := status.New(e.code, err.Error()).WithDetails(&errdetails.ErrorInfo{
+			Reason: e.reason,
+			Domain: productErrorDomain,
+		})
+		if detailsErr != nil {
+			return status.Error(e.code, err.Error())
+		}
+		return st.Err()
+	}
+	log.Println(err)
+	return err
+}
+
 func (s *grpcServer) PostProduct(ctx context.Context, r *pb.CreateProductRequest) (*pb.ProductResponse, error) {
-	p, err := s.service.PostProduct(ctx, r.GetName(), r.GetDescription(), r.GetPrice(), r.GetAccountId())
+	p, err := s.service.PostProduct(ctx, r.GetName(), r.GetDescription(), r.GetCategory(), fromProtoMoney(r.GetPrice()), fromProtoAttributes(r.GetAttributes()), r.GetAccountId())
 	if err != nil {
-		log.Println(err)
-		return nil, err
+		return nil, productStatus(err)
 	}
-	return &pb.ProductResponse{Product: &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		AccountId:   p.AccountID,
-	}}, nil
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
 }
 
 func (s *grpcServer) GetProduct(ctx context.Context, req *pb.ProductByIdRequest) (*pb.ProductResponse, error) {
 	p, err := s.service.GetProduct(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		return nil, productStatus(err)
 	}
 
-	return &pb.ProductResponse{Product: &pb.Product{
-		Id:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-		AccountId:   p.AccountID,
-	}}, nil
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
 }
 
 func (s *grpcServer) GetProducts(ctx context.Context, req *pb.GetProductsRequest) (*pb.ProductsResponse, error) {
@@ -65,53 +200,916 @@ func (s *grpcServer) GetProducts(ctx context.Context, req *pb.GetProductsRequest
 	if len(req.Ids) > 0 {
 		res, err := s.service.GetProductsWithIDs(ctx, req.Ids)
 		if err != nil {
-			return nil, err
+			return nil, productStatus(err)
 		}
 		products = res
-	} else if req.Query != "" {
-		res, err := s.service.SearchProducts(ctx, req.Query, req.Skip, req.Take)
+	} else if req.WithCursor {
+		return s.getProductsPage(ctx, req)
+	} else if req.Query != "" || req.MinRating > 0 || req.Sort != "" || len(req.Attributes) > 0 {
+		res, err := s.service.SearchProducts(ctx, req.Query, req.Skip, req.Take, req.MinRating, req.Sort, fromProtoAttributeFilters(req.Attributes))
 		if err != nil {
-			return nil, err
+			return nil, productStatus(err)
 		}
 		products = res
 	} else {
 		res, err := s.service.GetProducts(ctx, req.Skip, req.Take)
 		if err != nil {
-			return nil, err
+			return nil, productStatus(err)
 		}
 		products = res
 	}
 
 	var pbProducts []*pb.Product
 	for _, p := range products {
-		pbProducts = append(pbProducts, &pb.Product{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			AccountId:   p.AccountID,
-		})
+		pbProducts = append(pbProducts, toProtoProduct(&p))
 	}
 
 	return &pb.ProductsResponse{Products: pbProducts}, nil
 }
 
+func (s *grpcServer) ListProductsByAccount(ctx context.Context, r *pb.ListProductsByAccountRequest) (*pb.ProductsResponse, error) {
+	products, err := s.service.ListProductsByAccount(ctx, r.GetAccountId(), r.GetStatuses(), r.Archived, r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.ProductsResponse{}
+	for _, p := range products {
+		res.Products = append(res.Products, toProtoProduct(&p))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) getProductsPage(ctx context.Context, req *pb.GetProductsRequest) (*pb.ProductsResponse, error) {
+	page, err := s.service.SearchProductsPage(ctx, req.Query, req.MinRating, req.Sort, fromProtoAttributeFilters(req.Attributes), req.Take, req.Cursor)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.ProductsResponse{Cursors: page.Cursors, HasMore: page.HasMore}
+	for _, p := range page.Products {
+		res.Products = append(res.Products, toProtoProduct(&p))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) SuggestProducts(ctx context.Context, r *pb.SuggestProductsRequest) (*pb.SuggestProductsResponse, error) {
+	suggestions, err := s.service.SuggestProducts(ctx, r.GetPrefix(), int(r.GetSize()))
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.SuggestProductsResponse{Categories: suggestions.Categories}
+	for _, p := range suggestions.Products {
+		res.Products = append(res.Products, &pb.ProductSuggestion{
+			Id:   p.ID,
+			Name: p.Name,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *grpcServer) GetRelatedProducts(ctx context.Context, r *pb.GetRelatedProductsRequest) (*pb.ProductsResponse, error) {
+	products, err := s.service.GetRelatedProducts(ctx, r.GetProductId(), int(r.GetSize()))
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.ProductsResponse{}
+	for _, p := range products {
+		res.Products = append(res.Products, toProtoProduct(&p))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetSearchSynonyms(ctx context.Context, _ *emptypb.Empty) (*pb.SearchSynonymsResponse, error) {
+	rules, err := s.service.GetSearchSynonyms(ctx)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.SearchSynonymsResponse{Rules: rules}, nil
+}
+
+func (s *grpcServer) UpdateSearchSynonyms(ctx context.Context, r *pb.UpdateSearchSynonymsRequest) (*pb.SearchSynonymsResponse, error) {
+	rules, err := s.service.UpdateSearchSynonyms(ctx, r.GetRules())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.SearchSynonymsResponse{Rules: rules}, nil
+}
+
 func (s *grpcServer) UpdateProduct(ctx context.Context, r *pb.UpdateProductRequest) (*pb.ProductResponse, error) {
-	p, err := s.service.UpdateProduct(ctx, r.GetId(), r.GetName(), r.GetDescription(), r.GetPrice(), r.GetAccountId())
+	p, err := s.service.UpdateProduct(ctx, r.GetId(), r.GetName(), r.GetDescription(), r.GetCategory(), fromProtoMoney(r.GetPrice()), fromProtoAttributes(r.GetAttributes()), r.GetAccountId(), r.GetExpectedVersion(), r.GetUpdateMask().GetPaths())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) DeleteProduct(ctx context.Context, r *pb.DeleteProductRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteProduct(ctx, r.GetProductId(), r.GetAccountId()); err != nil {
+		return nil, productStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcServer) PublishProduct(ctx context.Context, r *pb.PublishProductRequest) (*pb.ProductResponse, error) {
+	var publishAt *time.Time
+	if len(r.GetPublishAt()) > 0 {
+		publishAt = &time.Time{}
+		if err := publishAt.UnmarshalBinary(r.GetPublishAt()); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid publishAt")
+		}
+	}
+
+	p, err := s.service.PublishProduct(ctx, r.GetProductId(), r.GetAccountId(), publishAt)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) UnpublishProduct(ctx context.Context, r *pb.UnpublishProductRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.UnpublishProduct(ctx, r.GetProductId(), r.GetAccountId())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) ReviewProduct(ctx context.Context, r *pb.ReviewProductRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.ReviewProduct(ctx, r.GetProductId(), r.GetApprove())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) RestoreProduct(ctx context.Context, r *pb.RestoreProductRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.RestoreProduct(ctx, r.GetProductId(), r.GetAccountId())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) PurgeArchivedProducts(ctx context.Context, r *pb.PurgeArchivedProductsRequest) (*pb.PurgeArchivedProductsResponse, error) {
+	purged, err := s.service.PurgeArchivedProducts(ctx, time.Duration(r.GetRetentionSeconds())*time.Second)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.PurgeArchivedProductsResponse{Purged: purged}, nil
+}
+
+func (s *grpcServer) UploadProductImage(stream pb.ProductService_UploadProductImageServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "image upload must start with image info")
+	}
+
+	var data bytes.Buffer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if data.Len()+len(req.GetChunk()) > maxImageSize {
+			return status.Error(codes.InvalidArgument, ErrImageTooLarge.Error())
+		}
+		data.Write(req.GetChunk())
+	}
+
+	log.Printf("Received image %q (%d bytes) for product %s", info.GetFilename(), data.Len(), info.GetProductId())
+
+	p, err := s.service.AddProductImage(stream.Context(), info.GetProductId(), info.GetAccountId(), &data)
+	if err != nil {
+		return productStatus(err)
+	}
+
+	return stream.SendAndClose(&pb.ProductResponse{Product: toProtoProduct(p)})
+}
+
+func (s *grpcServer) ImportProducts(stream pb.ProductService_ImportProductsServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	options := req.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "import must start with import options")
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if _, err := pw.Write(req.GetChunk()); err != nil {
+				return
+			}
+		}
+	}()
+
+	result, err := s.service.ImportProducts(stream.Context(), options.GetAccountId(), options.GetFormat(), pr)
+	if err != nil {
+		return productStatus(err)
+	}
+
+	res := &pb.ImportProductsResponse{
+		Imported: result.Imported,
+		Failed:   result.Failed,
+	}
+	for _, rowErr := range result.Errors {
+		res.Errors = append(res.Errors, &pb.ImportRowError{
+			Row:     rowErr.Row,
+			Message: rowErr.Message,
+		})
+	}
+
+	return stream.SendAndClose(res)
+}
+
+func (s *grpcServer) ExportProducts(r *pb.ExportProductsRequest, stream pb.ProductService_ExportProductsServer) error {
+	w := bufio.NewWriterSize(&exportWriter{stream: stream}, 64*1024)
+
+	if err := s.service.ExportProducts(stream.Context(), r.GetAccountId(), r.GetFormat(), w); err != nil {
+		return productStatus(err)
+	}
+
+	return w.Flush()
+}
+
+type exportWriter struct {
+	stream pb.ProductService_ExportProductsServer
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	if err := w.stream.Send(&pb.ExportProductsResponse{Chunk: chunk}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *grpcServer) RemoveProductImage(ctx context.Context, r *pb.RemoveProductImageRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.RemoveProductImage(ctx, r.GetProductId(), r.GetImageId(), r.GetAccountId())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) ReorderProductImages(ctx context.Context, r *pb.ReorderProductImagesRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.ReorderProductImages(ctx, r.GetProductId(), r.GetAccountId(), r.GetImageIds())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) PostReview(ctx context.Context, r *pb.PostReviewRequest) (*pb.ReviewResponse, error) {
+	review, err := s.service.PostReview(ctx, r.GetProductId(), r.GetAccountId(), int(r.GetRating()), r.GetTitle(), r.GetBody(), r.GetVerifiedPurchase())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ReviewResponse{Review: toProtoReview(review)}, nil
+}
+
+func (s *grpcServer) GetProductReviews(ctx context.Context, r *pb.GetProductReviewsRequest) (*pb.GetProductReviewsResponse, error) {
+	reviews, err := s.service.GetProductReviews(ctx, r.GetProductId(), r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.GetProductReviewsResponse{}
+	for _, review := range reviews {
+		res.Reviews = append(res.Reviews, toProtoReview(&review))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) ReplyToReview(ctx context.Context, r *pb.ReplyToReviewRequest) (*pb.ReviewResponse, error) {
+	review, err := s.service.ReplyToReview(ctx, r.GetReviewId(), r.GetAccountId(), r.GetBody())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ReviewResponse{Review: toProtoReview(review)}, nil
+}
+
+func (s *grpcServer) VoteReviewHelpful(ctx context.Context, r *pb.VoteReviewHelpfulRequest) (*pb.ReviewResponse, error) {
+	review, err := s.service.VoteReviewHelpful(ctx, r.GetReviewId(), r.GetAccountId())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ReviewResponse{Review: toProtoReview(review)}, nil
+}
+
+func (s *grpcServer) ModerateReview(ctx context.Context, r *pb.ModerateReviewRequest) (*pb.ReviewResponse, error) {
+	review, err := s.service.ModerateReview(ctx, r.GetReviewId(), r.GetStatus())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ReviewResponse{Review: toProtoReview(review)}, nil
+}
+
+func (s *grpcServer) SchedulePrice(ctx context.Context, r *pb.SchedulePriceRequest) (*pb.ProductResponse, error) {
+	var startsAt, endsAt time.Time
+	if err := startsAt.UnmarshalBinary(r.GetStartsAt()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid startsAt")
+	}
+	if err := endsAt.UnmarshalBinary(r.GetEndsAt()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid endsAt")
+	}
+
+	p, err := s.service.SchedulePrice(ctx, r.GetProductId(), r.GetAccountId(), fromProtoMoney(r.GetPrice()), startsAt, endsAt)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) CancelPriceSchedule(ctx context.Context, r *pb.CancelPriceScheduleRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.CancelPriceSchedule(ctx, r.GetProductId(), r.GetScheduleId(), r.GetAccountId())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) SetBundle(ctx context.Context, r *pb.SetBundleRequest) (*pb.ProductResponse, error) {
+	var components []BundleComponent
+	for _, c := range r.GetComponents() {
+		components = append(components, BundleComponent{ProductID: c.GetProductId(), Quantity: c.GetQuantity()})
+	}
+
+	p, err := s.service.SetBundle(ctx, r.GetProductId(), r.GetAccountId(), components)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) SetStock(ctx context.Context, r *pb.SetStockRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.SetStock(ctx, r.GetProductId(), r.GetAccountId(), r.Stock)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) ReserveStock(ctx context.Context, r *pb.StockRequest) (*emptypb.Empty, error) {
+	if err := s.service.ReserveStock(ctx, fromProtoStockLines(r.GetLines())); err != nil {
+		return nil, stockStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcServer) ReleaseStock(ctx context.Context, r *pb.StockRequest) (*emptypb.Empty, error) {
+	if err := s.service.ReleaseStock(ctx, fromProtoStockLines(r.GetLines())); err != nil {
+		return nil, stockStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func fromProtoStockLines(lines []*pb.StockLine) []StockLine {
+	var result []StockLine
+	for _, line := range lines {
+		result = append(result, StockLine{ProductID: line.GetProductId(), Quantity: line.GetQuantity()})
+	}
+	return result
+}
+
+// stockStatus maps stock errors so that Client can tell a shortage, the only
+// FailedPrecondition, from a repository without reservations
+func stockStatus(err error) error {
+	if errors.Is(err, ErrNoStockReservations) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return productStatus(err)
+}
+
+func (s *grpcServer) GetPriceHistory(ctx context.Context, r *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+	points, err := s.service.GetPriceHistory(ctx, r.GetProductId(), r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.GetPriceHistoryResponse{}
+	for _, point := range points {
+		pp := &pb.PricePoint{
+			Price:          toProtoMoney(point.Price),
+			CompareAtPrice: toProtoMoney(point.CompareAtPrice),
+			Reason:         point.Reason,
+		}
+		pp.ChangedAt, _ = point.ChangedAt.MarshalBinary()
+		res.Prices = append(res.Prices, pp)
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetExchangeRates(ctx context.Context, _ *emptypb.Empty) (*pb.ExchangeRatesResponse, error) {
+	rates, err := s.service.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ExchangeRatesResponse{Rates: toProtoExchangeRates(rates)}, nil
+}
+
+func (s *grpcServer) UpdateExchangeRates(ctx context.Context, r *pb.UpdateExchangeRatesRequest) (*pb.ExchangeRatesResponse, error) {
+	rates, err := s.service.UpdateExchangeRates(ctx, money.ExchangeRates{
+		Base:  r.GetRates().GetBase(),
+		Rates: r.GetRates().GetRates(),
+	})
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ExchangeRatesResponse{Rates: toProtoExchangeRates(rates)}, nil
+}
+
+func (s *grpcServer) GetCategorySchema(ctx context.Context, r *pb.GetCategorySchemaRequest) (*pb.CategorySchemaResponse, error) {
+	schema, err := s.service.GetCategorySchema(ctx, r.GetCategory())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.CategorySchemaResponse{Schema: toProtoCategorySchema(schema)}, nil
+}
+
+func (s *grpcServer) UpdateCategorySchema(ctx context.Context, r *pb.UpdateCategorySchemaRequest) (*pb.CategorySchemaResponse, error) {
+	schema, err := s.service.UpdateCategorySchema(ctx, fromProtoCategorySchema(r.GetSchema()))
 	if err != nil {
-		return nil, err
+		return nil, productStatus(err)
 	}
 
-	return &pb.ProductResponse{Product: &pb.Product{
+	return &pb.CategorySchemaResponse{Schema: toProtoCategorySchema(schema)}, nil
+}
+
+func toProtoCategorySchema(schema *CategorySchema) *pb.CategorySchema {
+	result := &pb.CategorySchema{Category: schema.Category}
+	result.UpdatedAt, _ = schema.UpdatedAt.MarshalBinary()
+	for _, a := range schema.Attributes {
+		result.Attributes = append(result.Attributes, &pb.AttributeDefinition{
+			Name:          a.Name,
+			Label:         a.Label,
+			Type:          a.Type,
+			Unit:          a.Unit,
+			AllowedValues: a.AllowedValues,
+			Required:      a.Required,
+		})
+	}
+	return result
+}
+
+func fromProtoCategorySchema(schema *pb.CategorySchema) CategorySchema {
+	result := CategorySchema{Category: schema.GetCategory()}
+	result.UpdatedAt.UnmarshalBinary(schema.GetUpdatedAt())
+	for _, a := range schema.GetAttributes() {
+		result.Attributes = append(result.Attributes, AttributeDefinition{
+			Name:          a.Name,
+			Label:         a.Label,
+			Type:          a.Type,
+			Unit:          a.Unit,
+			AllowedValues: a.AllowedValues,
+			Required:      a.Required,
+		})
+	}
+	return result
+}
+
+func toProtoAttributes(attributes []ProductAttribute) []*pb.ProductAttribute {
+	var result []*pb.ProductAttribute
+	for _, a := range attributes {
+		result = append(result, &pb.ProductAttribute{Name: a.Name, Value: a.Value, Unit: a.Unit})
+	}
+	return result
+}
+
+func fromProtoAttributes(attributes []*pb.ProductAttribute) []ProductAttribute {
+	var result []ProductAttribute
+	for _, a := range attributes {
+		result = append(result, ProductAttribute{Name: a.Name, Value: a.Value, Unit: a.Unit})
+	}
+	return result
+}
+
+func toProtoAttributeFilters(filters []AttributeFilter) []*pb.AttributeFilter {
+	var result []*pb.AttributeFilter
+	for _, f := range filters {
+		result = append(result, &pb.AttributeFilter{Name: f.Name, Values: f.Values, Min: f.Min, Max: f.Max})
+	}
+	return result
+}
+
+func fromProtoAttributeFilters(filters []*pb.AttributeFilter) []AttributeFilter {
+	var result []AttributeFilter
+	for _, f := range filters {
+		result = append(result, AttributeFilter{Name: f.Name, Values: f.Values, Min: f.Min, Max: f.Max})
+	}
+	return result
+}
+
+func toProtoExchangeRates(r *money.ExchangeRates) *pb.ExchangeRates {
+	rates := &pb.ExchangeRates{Base: r.Base, Rates: r.Rates}
+	rates.UpdatedAt, _ = r.UpdatedAt.MarshalBinary()
+	return rates
+}
+
+func toProtoReview(r *Review) *pb.Review {
+	review := &pb.Review{
+		Id:               r.ID,
+		ProductId:        r.ProductID,
+		AccountId:        r.AccountID,
+		Rating:           int32(r.Rating),
+		Title:            r.Title,
+		Body:             r.Body,
+		VerifiedPurchase: r.VerifiedPurchase,
+		Status:           r.Status,
+		HelpfulVotes:     int32(r.HelpfulVotes),
+	}
+	review.CreatedAt, _ = r.CreatedAt.MarshalBinary()
+	if r.Reply != nil {
+		review.Reply = &pb.ReviewReply{Body: r.Reply.Body}
+		review.Reply.CreatedAt, _ = r.Reply.CreatedAt.MarshalBinary()
+	}
+	return review
+}
+
+func toProtoMoney(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+func fromProtoMoney(m *pb.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
+
+func toProtoProduct(p *Product) *pb.Product {
+	product := &pb.Product{
 		Id:          p.ID,
 		Name:        p.Name,
 		Description: p.Description,
-		Price:       p.Price,
+		Category:    p.Category,
+		Price:       toProtoMoney(p.Price),
 		AccountId:   p.AccountID,
-	}}, nil
+		Version:     p.Version,
+		Rating:      p.Rating,
+		ReviewCount: int32(p.ReviewCount),
+
+		RegularPrice:   toProtoMoney(p.RegularPrice),
+		CompareAtPrice: toProtoMoney(p.CompareAtPrice()),
+		Attributes:     toProtoAttributes(p.Attributes),
+		Status:         p.Status,
+		Stock:          p.Stock,
+	}
+	for _, c := range p.Bundle {
+		product.Bundle = append(product.Bundle, &pb.BundleComponent{ProductId: c.ProductID, Quantity: c.Quantity})
+	}
+	if p.Screening != nil {
+		product.Screening = &pb.Screening{
+			Status:  p.Screening.Status,
+			Reasons: p.Screening.Reasons,
+			Note:    p.Screening.Note,
+		}
+		product.Screening.FlaggedAt, _ = p.Screening.FlaggedAt.MarshalBinary()
+		if p.Screening.ReviewedAt != nil {
+			product.Screening.ReviewedAt, _ = p.Screening.ReviewedAt.MarshalBinary()
+		}
+	}
+	if p.ArchivedAt != nil {
+		product.ArchivedAt, _ = p.ArchivedAt.MarshalBinary()
+	}
+	if p.PublishAt != nil {
+		product.PublishAt, _ = p.PublishAt.MarshalBinary()
+	}
+
+	for _, schedule := range p.PriceSchedules {
+		ps := &pb.PriceSchedule{Id: schedule.ID, Price: toProtoMoney(schedule.Price)}
+		ps.StartsAt, _ = schedule.StartsAt.MarshalBinary()
+		ps.EndsAt, _ = schedule.EndsAt.MarshalBinary()
+		product.PriceSchedules = append(product.PriceSchedules, ps)
+	}
+
+	for _, img := range p.Images {
+		image := &pb.ProductImage{
+			Id:       img.ID,
+			Url:      img.URL,
+			Width:    int32(img.Width),
+			Height:   int32(img.Height),
+			Position: int32(img.Position),
+		}
+		for _, t := range img.Thumbnails {
+			image.Thumbnails = append(image.Thumbnails, &pb.Thumbnail{
+				Size:   t.Size,
+				Url:    t.URL,
+				Width:  int32(t.Width),
+				Height: int32(t.Height),
+			})
+		}
+		product.Images = append(product.Images, image)
+	}
+
+	return product
 }
 
-func (s *grpcServer) DeleteProduct(ctx context.Context, r *pb.DeleteProductRequest) (*emptypb.Empty, error) {
-	err := s.service.DeleteProduct(ctx, r.GetProductId(), r.GetAccountId())
-	return &emptypb.Empty{}, err
+func (s *grpcServer) ListWishlists(ctx context.Context, r *pb.ListWishlistsRequest) (*pb.ListWishlistsResponse, error) {
+	lists, err := s.service.ListWishlists(ctx, r.GetAccountId(), r.GetPublicOnly())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.ListWishlistsResponse{}
+	for _, w := range lists {
+		res.Wishlists = append(res.Wishlists, toProtoWishlist(&w))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetSharedWishlist(ctx context.Context, r *pb.GetSharedWishlistRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.GetSharedWishlist(ctx, r.GetShareToken()))
+}
+
+func (s *grpcServer) CreateWishlist(ctx context.Context, r *pb.CreateWishlistRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.CreateWishlist(ctx, r.GetAccountId(), r.GetName(), r.GetPublic()))
+}
+
+func (s *grpcServer) UpdateWishlist(ctx context.Context, r *pb.UpdateWishlistRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.UpdateWishlist(ctx, r.GetId(), r.GetAccountId(), r.Name, r.Public))
+}
+
+func (s *grpcServer) DeleteWishlist(ctx context.Context, r *pb.DeleteWishlistRequest) (*emptypb.Empty, error) {
+	if err := s.service.DeleteWishlist(ctx, r.GetId(), r.GetAccountId()); err != nil {
+		return nil, wishlistStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *grpcServer) AddToWishlist(ctx context.Context, r *pb.AddToWishlistRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.AddToWishlist(ctx, r.GetAccountId(), r.GetWishlistId(), r.GetProductId()))
+}
+
+func (s *grpcServer) RemoveFromWishlist(ctx context.Context, r *pb.RemoveFromWishlistRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.RemoveFromWishlist(ctx, r.GetAccountId(), r.GetWishlistId(), r.GetProductId()))
+}
+
+func (s *grpcServer) MoveWishlistItem(ctx context.Context, r *pb.MoveWishlistItemRequest) (*pb.WishlistResponse, error) {
+	return wishlistResponse(s.service.MoveWishlistItem(ctx, r.GetAccountId(), r.GetProductId(), r.GetFromWishlistId(), r.GetToWishlistId()))
+}
+
+func wishlistResponse(w *Wishlist, err error) (*pb.WishlistResponse, error) {
+	if err != nil {
+		return nil, wishlistStatus(err)
+	}
+	return &pb.WishlistResponse{Wishlist: toProtoWishlist(w)}, nil
+}
+
+// wishlistStatus maps wishlist errors to status codes that Client maps back
+func wishlistStatus(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, ErrWishlistExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.Is(err, ErrInvalidWishlistName) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, ErrDefaultWishlist) || errors.Is(err, ErrTooManyWishlists) || errors.Is(err, ErrWishlistFull) || errors.Is(err, ErrNotPublished) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func toProtoWishlist(w *Wishlist) *pb.Wishlist {
+	wishlist := &pb.Wishlist{
+		Id:         w.ID,
+		AccountId:  w.AccountID,
+		Name:       w.Name,
+		IsDefault:  w.Default,
+		ShareToken: w.ShareToken,
+	}
+	wishlist.CreatedAt, _ = w.CreatedAt.MarshalBinary()
+	for _, item := range w.Items {
+		addedAt, _ := item.AddedAt.MarshalBinary()
+		wishlist.Items = append(wishlist.Items, &pb.WishlistItem{ProductId: item.ProductID, AddedAt: addedAt})
+	}
+	return wishlist
+}
+
+func (s *grpcServer) AskQuestion(ctx context.Context, r *pb.AskQuestionRequest) (*pb.QuestionResponse, error) {
+	return questionResponse(s.service.AskQuestion(ctx, r.GetProductId(), r.GetAccountId(), r.GetBody()))
+}
+
+func (s *grpcServer) GetProductQuestions(ctx context.Context, r *pb.GetProductQuestionsRequest) (*pb.GetProductQuestionsResponse, error) {
+	questions, err := s.service.GetProductQuestions(ctx, r.GetProductId(), r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, questionStatus(err)
+	}
+
+	res := &pb.GetProductQuestionsResponse{}
+	for _, q := range questions {
+		res.Questions = append(res.Questions, toProtoQuestion(&q))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) GetQuestion(ctx context.Context, r *pb.GetQuestionRequest) (*pb.QuestionResponse, error) {
+	return questionResponse(s.service.GetQuestion(ctx, r.GetQuestionId()))
+}
+
+func (s *grpcServer) AnswerQuestion(ctx context.Context, r *pb.AnswerQuestionRequest) (*pb.QuestionResponse, error) {
+	return questionResponse(s.service.AnswerQuestion(ctx, r.GetQuestionId(), r.GetAccountId(), r.GetBody(), r.GetVerifiedPurchase()))
+}
+
+func (s *grpcServer) ModerateQuestion(ctx context.Context, r *pb.ModerateQuestionRequest) (*pb.QuestionResponse, error) {
+	return questionResponse(s.service.ModerateQuestion(ctx, r.GetQuestionId(), r.GetStatus()))
+}
+
+func (s *grpcServer) ModerateAnswer(ctx context.Context, r *pb.ModerateAnswerRequest) (*pb.QuestionResponse, error) {
+	return questionResponse(s.service.ModerateAnswer(ctx, r.GetAnswerId(), r.GetStatus()))
+}
+
+func questionResponse(q *Question, err error) (*pb.QuestionResponse, error) {
+	if err != nil {
+		return nil, questionStatus(err)
+	}
+	return &pb.QuestionResponse{Question: toProtoQuestion(q)}, nil
+}
+
+// questionStatus maps question errors to status codes. Client only maps
+// NotFound back and keeps the server's message for the others.
+func questionStatus(err error) error {
+	if errors.Is(err, ErrNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, ErrInvalidQuestion) || errors.Is(err, ErrInvalidAnswer) || errors.Is(err, ErrInvalidReviewStatus) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, ErrOwnQuestion) || errors.Is(err, ErrNotBuyer) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, ErrNotPublished) || errors.Is(err, ErrNoQuestions) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func toProtoQuestion(q *Question) *pb.Question {
+	question := &pb.Question{
+		Id:        q.ID,
+		ProductId: q.ProductID,
+		AccountId: q.AccountID,
+		Body:      q.Body,
+		Status:    q.Status,
+	}
+	question.CreatedAt, _ = q.CreatedAt.MarshalBinary()
+	for _, a := range q.Answers {
+		answer := &pb.Answer{
+			Id:               a.ID,
+			QuestionId:       a.QuestionID,
+			AccountId:        a.AccountID,
+			Body:             a.Body,
+			FromSeller:       a.FromSeller,
+			VerifiedPurchase: a.VerifiedPurchase,
+			Status:           a.Status,
+		}
+		answer.CreatedAt, _ = a.CreatedAt.MarshalBinary()
+		question.Answers = append(question.Answers, answer)
+	}
+	return question
+}
+
+func (s *grpcServer) GetProductHistory(ctx context.Context, r *pb.GetProductHistoryRequest) (*pb.GetProductHistoryResponse, error) {
+	versions, err := s.service.GetProductHistory(ctx, r.GetProductId(), r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.GetProductHistoryResponse{}
+	for _, v := range versions {
+		version := &pb.ProductVersion{
+			Version:   v.Version,
+			ChangedBy: v.ChangedBy,
+		}
+		version.ChangedAt, _ = v.ChangedAt.MarshalBinary()
+		for _, c := range v.Changes {
+			version.Changes = append(version.Changes, &pb.FieldChange{Field: c.Field, From: c.From, To: c.To})
+		}
+		res.Versions = append(res.Versions, version)
+	}
+	return res, nil
+}
+
+func (s *grpcServer) RevertProduct(ctx context.Context, r *pb.RevertProductRequest) (*pb.ProductResponse, error) {
+	p, err := s.service.RevertProduct(ctx, r.GetProductId(), r.GetAccountId(), r.GetVersion())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
+}
+
+func (s *grpcServer) GetScreeningRules(ctx context.Context, _ *emptypb.Empty) (*pb.ScreeningRulesResponse, error) {
+	rules, err := s.service.GetScreeningRules(ctx)
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ScreeningRulesResponse{Rules: toProtoScreeningRules(rules)}, nil
+}
+
+func (s *grpcServer) UpdateScreeningRules(ctx context.Context, r *pb.UpdateScreeningRulesRequest) (*pb.ScreeningRulesResponse, error) {
+	rules, err := s.service.UpdateScreeningRules(ctx, ScreeningRules{
+		BannedTerms:      r.GetRules().GetBannedTerms(),
+		MinPrice:         fromProtoMoney(r.GetRules().GetMinPrice()),
+		MaxPrice:         fromProtoMoney(r.GetRules().GetMaxPrice()),
+		DetectDuplicates: r.GetRules().GetDetectDuplicates(),
+	})
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ScreeningRulesResponse{Rules: toProtoScreeningRules(rules)}, nil
+}
+
+func toProtoScreeningRules(r *ScreeningRules) *pb.ScreeningRules {
+	rules := &pb.ScreeningRules{
+		BannedTerms:      r.BannedTerms,
+		MinPrice:         toProtoMoney(r.MinPrice),
+		MaxPrice:         toProtoMoney(r.MaxPrice),
+		DetectDuplicates: r.DetectDuplicates,
+	}
+	if !r.UpdatedAt.IsZero() {
+		rules.UpdatedAt, _ = r.UpdatedAt.MarshalBinary()
+	}
+	return rules
+}
+
+func (s *grpcServer) ListFlaggedProducts(ctx context.Context, r *pb.ListFlaggedProductsRequest) (*pb.ProductsResponse, error) {
+	products, err := s.service.ListFlaggedProducts(ctx, r.GetSkip(), r.GetTake())
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	res := &pb.ProductsResponse{}
+	for _, p := range products {
+		res.Products = append(res.Products, toProtoProduct(&p))
+	}
+	return res, nil
+}
+
+func (s *grpcServer) ApproveProduct(ctx context.Context, r *pb.ApproveProductRequest) (*pb.ProductResponse, error) {
+	return moderatedProductResponse(s.service.ApproveProduct(ctx, r.GetProductId()))
+}
+
+func (s *grpcServer) RejectProduct(ctx context.Context, r *pb.RejectProductRequest) (*pb.ProductResponse, error) {
+	return moderatedProductResponse(s.service.RejectProduct(ctx, r.GetProductId(), r.GetNote()))
+}
+
+func moderatedProductResponse(p *Product, err error) (*pb.ProductResponse, error) {
+	if err != nil {
+		return nil, productStatus(err)
+	}
+
+	return &pb.ProductResponse{Product: toProtoProduct(p)}, nil
 }
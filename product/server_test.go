@@ -0,0 +1,71 @@
+package product
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProductStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+		want error
+	}{
+		{name: "not found", err: ErrNotFound, code: codes.NotFound, want: ErrNotFound},
+		{name: "unauthorized", err: ErrUnauthorized, code: codes.PermissionDenied, want: ErrUnauthorized},
+		{name: "version conflict", err: ErrVersionConflict, code: codes.Aborted, want: ErrVersionConflict},
+		{name: "review exists", err: ErrReviewExists, code: codes.AlreadyExists, want: ErrReviewExists},
+		{name: "wrapped invalid attribute", err: fmt.Errorf("%w: color is required", ErrInvalidAttribute), code: codes.InvalidArgument, want: ErrInvalidAttribute},
+		{name: "money error", err: money.ErrCurrencyMismatch, code: codes.InvalidArgument, want: money.ErrCurrencyMismatch},
+		{name: "invalid status transition", err: ErrInvalidStatusTransition, code: codes.FailedPrecondition, want: ErrInvalidStatusTransition},
+		{name: "not published shares its code", err: ErrNotPublished, code: codes.FailedPrecondition, want: ErrNotPublished},
+		{name: "insufficient stock shares its code", err: ErrInsufficientStock, code: codes.FailedPrecondition, want: ErrInsufficientStock},
+		{name: "cursor expired shares its code", err: ErrCursorExpired, code: codes.FailedPrecondition, want: ErrCursorExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := productStatus(tt.err)
+			if got := status.Code(err); got != tt.code {
+				t.Errorf("productStatus() code = %v, want %v", got, tt.code)
+			}
+			if got := status.Convert(err).Message(); got != tt.err.Error() {
+				t.Errorf("productStatus() message = %q, want %q", got, tt.err.Error())
+			}
+			if got := fromProductStatus(err); got != tt.want {
+				t.Errorf("fromProductStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductStatusUnknownError(t *testing.T) {
+	err := errors.New("connection refused")
+	if got := productStatus(err); got != err {
+		t.Errorf("productStatus() = %v, want the error as it is", got)
+	}
+
+	plain := status.Error(codes.FailedPrecondition, "no reason")
+	if got := fromProductStatus(plain); got != plain {
+		t.Errorf("fromProductStatus() = %v, want the status as it is", got)
+	}
+	if got := fromProductStatus(nil); got != nil {
+		t.Errorf("fromProductStatus(nil) = %v, want nil", got)
+	}
+}
+
+func TestProductErrorReasons(t *testing.T) {
+	reasons := map[string]error{}
+	for _, e := range productErrors {
+		if other, ok := reasons[e.reason]; ok {
+			t.Errorf("reason %s is used by %v and %v", e.reason, other, e.err)
+		}
+		reasons[e.reason] = e.err
+	}
+}
@@ -3,72 +3,209 @@ package product
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"slices"
+	"time"
 
 	"github.com/IBM/sarama"
+	"github.com/go-systems-lab/go-ecommerce-lld/pkg/money"
 	"github.com/google/uuid"
 )
 
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	AccountID   string  `json:"accountId"`
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Category    string         `json:"category"`
+	Price       money.Money    `json:"price"`
+	AccountID   string         `json:"accountId"`
+	Images      []ProductImage `json:"images"`
+	Version     string         `json:"version"`
+	ArchivedAt  *time.Time     `json:"archivedAt,omitempty"`
+	Rating      float64        `json:"rating"`
+	ReviewCount int            `json:"reviewCount"`
+
+	// Price is the current price. RegularPrice applies whenever no scheduled
+	// price is active.
+	RegularPrice      money.Money     `json:"regularPrice"`
+	PriceSchedules    []PriceSchedule `json:"priceSchedules"`
+	NextPriceChangeAt *time.Time      `json:"nextPriceChangeAt,omitempty"`
+
+	Attributes []ProductAttribute `json:"attributes"`
+
+	// Status is the listing status. A published product is hidden until
+	// PublishAt when it is set.
+	Status    string     `json:"status"`
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
+	// Popularity is counted from interaction events and is not changed by
+	// product writes
+	Popularity *Popularity `json:"popularity,omitempty"`
+
+	// Stock is the number of units that can be ordered, nil when it is not
+	// tracked. The stock of a bundle is derived from its components.
+	Stock  *int64            `json:"stock,omitempty"`
+	Bundle []BundleComponent `json:"bundle,omitempty"`
+
+	// Screening is set when the product broke a screening rule, see
+	// ScreeningRules
+	Screening *Screening `json:"screening,omitempty"`
 }
 
+var (
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrInvalidUpdateMask = errors.New("invalid update mask")
+	ErrInvalidSort       = errors.New("invalid sort, expected relevance, rating or best_selling")
+)
+
+const (
+	SortRelevance   = ""
+	SortRating      = "rating"
+	SortBestSelling = "best_selling"
+)
+
+var searchSorts = []string{SortRelevance, SortRating, SortBestSelling}
+
+// updatableFields are the update mask paths accepted by UpdateProduct
+var updatableFields = []string{"name", "description", "category", "price", "attributes"}
+
 type Service interface {
-	PostProduct(ctx context.Context, name, description string, price float64, accountId string) (*Product, error)
+	PostProduct(ctx context.Context, name, description, category string, price money.Money, attributes []ProductAttribute, accountId string) (*Product, error)
 	GetProduct(ctx context.Context, id string) (*Product, error)
 	GetProducts(ctx context.Context, skip, take uint64) ([]Product, error)
 	GetProductsWithIDs(ctx context.Context, ids []string) ([]Product, error)
-	SearchProducts(ctx context.Context, query string, skip, take uint64) ([]Product, error)
-	UpdateProduct(ctx context.Context, id, name, description string, price float64, accountId string) (*Product, error)
+	ListProductsByAccount(ctx context.Context, accountId string, statuses []string, archived *bool, skip, take uint64) ([]Product, error)
+	SearchProducts(ctx context.Context, query string, skip, take uint64, minRating float64, sort string, attributes []AttributeFilter) ([]Product, error)
+	SearchProductsPage(ctx context.Context, query string, minRating float64, sort string, attributes []AttributeFilter, take uint64, cursor string) (*ProductPage, error)
+	SuggestProducts(ctx context.Context, prefix string, size int) (*Suggestions, error)
+	GetRelatedProducts(ctx context.Context, productId string, size int) ([]Product, error)
+	SetBundle(ctx context.Context, productId, accountId string, components []BundleComponent) (*Product, error)
+	SetStock(ctx context.Context, productId, accountId string, stock *int64) (*Product, error)
+	ReserveStock(ctx context.Context, lines []StockLine) error
+	ReleaseStock(ctx context.Context, lines []StockLine) error
+	GetSearchSynonyms(ctx context.Context) ([]string, error)
+	UpdateSearchSynonyms(ctx context.Context, rules []string) ([]string, error)
+	UpdateProduct(ctx context.Context, id, name, description, category string, price money.Money, attributes []ProductAttribute, accountId, expectedVersion string, paths []string) (*Product, error)
 	DeleteProduct(ctx context.Context, productId string, accountId string) error
+	RestoreProduct(ctx context.Context, productId, accountId string) (*Product, error)
+	PurgeArchivedProducts(ctx context.Context, retention time.Duration) (uint64, error)
+	PostReview(ctx context.Context, productId, accountId string, rating int, title, body string, verifiedPurchase bool) (*Review, error)
+	GetProductReviews(ctx context.Context, productId string, skip, take uint64) ([]Review, error)
+	ReplyToReview(ctx context.Context, reviewId, accountId, body string) (*Review, error)
+	VoteReviewHelpful(ctx context.Context, reviewId, accountId string) (*Review, error)
+	ModerateReview(ctx context.Context, reviewId, status string) (*Review, error)
+	SchedulePrice(ctx context.Context, productId, accountId string, price money.Money, startsAt, endsAt time.Time) (*Product, error)
+	CancelPriceSchedule(ctx context.Context, productId, scheduleId, accountId string) (*Product, error)
+	GetPriceHistory(ctx context.Context, productId string, skip, take uint64) ([]PricePoint, error)
+	ApplyScheduledPrices(ctx context.Context) (int, error)
+	AddProductImage(ctx context.Context, productId, accountId string, r io.Reader) (*Product, error)
+	RemoveProductImage(ctx context.Context, productId, imageId, accountId string) (*Product, error)
+	ReorderProductImages(ctx context.Context, productId, accountId string, imageIds []string) (*Product, error)
+	ImportProducts(ctx context.Context, accountId, format string, r io.Reader) (*ImportResult, error)
+	ExportProducts(ctx context.Context, accountId, format string, w io.Writer) error
+	GetExchangeRates(ctx context.Context) (*money.ExchangeRates, error)
+	UpdateExchangeRates(ctx context.Context, rates money.ExchangeRates) (*money.ExchangeRates, error)
+	GetCategorySchema(ctx context.Context, category string) (*CategorySchema, error)
+	UpdateCategorySchema(ctx context.Context, schema CategorySchema) (*CategorySchema, error)
+	PublishProduct(ctx context.Context, productId, accountId string, publishAt *time.Time) (*Product, error)
+	UnpublishProduct(ctx context.Context, productId, accountId string) (*Product, error)
+	ReviewProduct(ctx context.Context, productId string, approve bool) (*Product, error)
+	ListWishlists(ctx context.Context, accountId string, publicOnly bool) ([]Wishlist, error)
+	GetSharedWishlist(ctx context.Context, token string) (*Wishlist, error)
+	CreateWishlist(ctx context.Context, accountId, name string, public bool) (*Wishlist, error)
+	UpdateWishlist(ctx context.Context, id, accountId string, name *string, public *bool) (*Wishlist, error)
+	DeleteWishlist(ctx context.Context, id, accountId string) error
+	AddToWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error)
+	RemoveFromWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error)
+	MoveWishlistItem(ctx context.Context, accountId, productId, fromId, toId string) (*Wishlist, error)
+	AskQuestion(ctx context.Context, productId, accountId, body string) (*Question, error)
+	GetProductQuestions(ctx context.Context, productId string, skip, take uint64) ([]Question, error)
+	GetQuestion(ctx context.Context, id string) (*Question, error)
+	AnswerQuestion(ctx context.Context, questionId, accountId, body string, verifiedPurchase bool) (*Question, error)
+	ModerateQuestion(ctx context.Context, questionId, status string) (*Question, error)
+	ModerateAnswer(ctx context.Context, answerId, status string) (*Question, error)
+	GetProductHistory(ctx context.Context, productId string, skip, take uint64) ([]ProductVersion, error)
+	RevertProduct(ctx context.Context, productId, accountId, version string) (*Product, error)
+	GetScreeningRules(ctx context.Context) (*ScreeningRules, error)
+	UpdateScreeningRules(ctx context.Context, rules ScreeningRules) (*ScreeningRules, error)
+	ListFlaggedProducts(ctx context.Context, skip, take uint64) ([]Product, error)
+	ApproveProduct(ctx context.Context, productId string) (*Product, error)
+	RejectProduct(ctx context.Context, productId, note string) (*Product, error)
 }
 
 type productService struct {
-	repo     Repository
-	producer sarama.AsyncProducer
+	repo      Repository
+	wishlists WishlistRepository
+	questions QuestionRepository
+	history   HistoryRepository
+	stock     StockRepository
+	producer  sarama.AsyncProducer
+	store     BlobStore
+	// reviewListings holds published products for ReviewProduct
+	reviewListings bool
 }
 
-func NewProductService(repo Repository, producer sarama.AsyncProducer) Service {
-	return &productService{repo: repo, producer: producer}
+// NewProductService creates the service. Wishlists, questions, product
+// history and stock reservations are only available when repo also
+// implements WishlistRepository, QuestionRepository, HistoryRepository and
+// StockRepository.
+func NewProductService(repo Repository, producer sarama.AsyncProducer, store BlobStore, reviewListings bool) Service {
+	wishlists, _ := repo.(WishlistRepository)
+	questions, _ := repo.(QuestionRepository)
+	history, _ := repo.(HistoryRepository)
+	stock, _ := repo.(StockRepository)
+	return &productService{repo: repo, wishlists: wishlists, questions: questions, history: history, stock: stock, producer: producer, store: store, reviewListings: reviewListings}
 }
 
-func (p productService) PostProduct(ctx context.Context, name, description string, price float64, accountId string) (*Product, error) {
+func (p productService) PostProduct(ctx context.Context, name, description, category string, price money.Money, attributes []ProductAttribute, accountId string) (*Product, error) {
 	log.Printf("PostProduct called with accountId: %s (type: %T)", accountId, accountId)
 
+	price, err := validatePrice(price)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := p.categorySchema(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	if attributes, err = schema.validateAttributes(attributes); err != nil {
+		return nil, err
+	}
+
 	product := Product{
 		ID:          uuid.New().String(),
 		Name:        name,
 		Description: description,
+		Category:    category,
 		Price:       price,
 		AccountID:   accountId,
+
+		RegularPrice: price,
+		Attributes:   attributes,
+
+		Status: StatusDraft,
+	}
+
+	rules, err := p.screeningRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if product.Screening, err = p.screen(ctx, rules, product); err != nil {
+		return nil, err
 	}
 
 	log.Printf("Created product struct: %+v", product)
 
-	if err := p.repo.PutProduct(ctx, product); err != nil {
+	version, err := p.repo.PutProduct(ctx, product, newProductEvent("product_created", product))
+	if err != nil {
 		log.Printf("Error from repository.PutProduct: %v", err)
 		return nil, err
 	}
-
-	go func() {
-		err := p.SendMessageToRecommender(Event{
-			Type: "product_created",
-			Data: EventData{
-				ID:          &product.ID,
-				Name:        &product.Name,
-				Description: &product.Description,
-				Price:       &product.Price,
-				AccountID:   &accountId,
-			},
-		}, "product_events")
-		if err != nil {
-			log.Printf("Error sending message to recommender: %v", err)
-		}
-	}()
+	product.Version = version
+	p.recordPrices(ctx, PriceReasonCreated, time.Now().UTC(), product)
 
 	log.Printf("Successfully stored product in repository")
 	return &product, nil
@@ -79,6 +216,13 @@ func (p productService) GetProduct(ctx context.Context, id string) (*Product, er
 	if err != nil {
 		return nil, err
 	}
+	if product.IsBundle() {
+		products, err := p.deriveBundleStock(ctx, []Product{*product})
+		if err != nil {
+			return nil, err
+		}
+		product = &products[0]
+	}
 
 	go func() {
 		err := p.SendMessageToRecommender(Event{
@@ -87,7 +231,7 @@ func (p productService) GetProduct(ctx context.Context, id string) (*Product, er
 				ID:        &product.ID,
 				AccountID: &product.AccountID,
 			},
-		}, "interaction_events")
+		}, InteractionEventsTopic)
 		if err != nil {
 			log.Printf("Error sending message to recommender: %v", err)
 		}
@@ -107,58 +251,133 @@ func (p productService) GetProducts(ctx context.Context, skip, take uint64) ([]P
 		log.Printf("Product %d: ID=%s, Name=%s, AccountID=%s", i, product.ID, product.Name, product.AccountID)
 	}
 
-	return products, nil
+	return p.deriveBundleStock(ctx, products)
 }
 
 func (p productService) GetProductsWithIDs(ctx context.Context, ids []string) ([]Product, error) {
-	return p.repo.ListProductsWithIds(ctx, ids)
+	products, err := p.repo.ListProductsWithIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return p.deriveBundleStock(ctx, products)
 }
 
-func (p productService) SearchProducts(ctx context.Context, query string, skip, take uint64) ([]Product, error) {
-	return p.repo.SearchProducts(ctx, query, skip, take)
+func (p productService) SearchProducts(ctx context.Context, query string, skip, take uint64, minRating float64, sort string, attributes []AttributeFilter) ([]Product, error) {
+	if !slices.Contains(searchSorts, sort) {
+		return nil, ErrInvalidSort
+	}
+	if err := validateAttributeFilters(attributes); err != nil {
+		return nil, err
+	}
+	products, err := p.repo.SearchProducts(ctx, query, skip, take, minRating, sort, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return p.deriveBundleStock(ctx, products)
 }
 
-func (p productService) UpdateProduct(ctx context.Context, id, name, description string, price float64, accountId string) (*Product, error) {
+// UpdateProduct overwrites the fields named in paths on a product owned by
+// accountId, or every updatable field if paths is empty. If expectedVersion is
+// set the update is rejected with ErrVersionConflict unless it matches the
+// stored version. When listings are reviewed, changing the name, description
+// or price of a published product sends it back to review.
+func (p productService) UpdateProduct(ctx context.Context, id, name, description, category string, price money.Money, attributes []ProductAttribute, accountId, expectedVersion string, paths []string) (*Product, error) {
+	if len(paths) == 0 {
+		paths = updatableFields
+	}
+	if err := validateUpdateMask(paths); err != nil {
+		return nil, err
+	}
+	if slices.Contains(paths, "price") {
+		var err error
+		if price, err = validatePrice(price); err != nil {
+			return nil, err
+		}
+	}
+
 	product, err := p.repo.GetProductById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if product.AccountID != accountId {
-		return nil, errors.New("unauthorized")
+		return nil, ErrUnauthorized
 	}
 
-	updatedProduct := Product{
-		ID:          id,
-		Name:        name,
-		Description: description,
-		Price:       price,
-		AccountID:   accountId,
+	if expectedVersion != "" && expectedVersion != product.Version {
+		return nil, ErrVersionConflict
 	}
 
-	if err = p.repo.UpdateProduct(ctx, updatedProduct); err != nil {
-		return nil, err
+	updatedProduct := *product
+	for _, path := range paths {
+		switch path {
+		case "name":
+			updatedProduct.Name = name
+		case "description":
+			updatedProduct.Description = description
+		case "category":
+			updatedProduct.Category = category
+		case "price":
+			// Scheduled prices are in the regular price's currency
+			if len(product.PriceSchedules) > 0 && price.Currency != product.RegularPrice.Currency {
+				return nil, money.ErrCurrencyMismatch
+			}
+			updatedProduct.RegularPrice = price
+		case "attributes":
+			updatedProduct.Attributes = attributes
+		}
 	}
 
-	go func() {
-		err := p.SendMessageToRecommender(Event{
-			Type: "product_updated",
-			Data: EventData{
-				ID:          &updatedProduct.ID,
-				Name:        &updatedProduct.Name,
-				Description: &updatedProduct.Description,
-				Price:       &updatedProduct.Price,
-				AccountID:   &accountId,
-			},
-		}, "product_events")
+	// Attributes are checked against the schema of the new category when
+	// either changes
+	if slices.Contains(paths, "attributes") || slices.Contains(paths, "category") {
+		schema, err := p.categorySchema(ctx, updatedProduct.Category)
 		if err != nil {
-			log.Printf("Error sending message to recommender: %v", err)
+			return nil, err
 		}
-	}()
+		if updatedProduct.Attributes, err = schema.validateAttributes(updatedProduct.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	p.reviewEdit(*product, &updatedProduct)
+
+	now := time.Now().UTC()
+	updatedProduct.applyPriceSchedules(now)
+
+	rules, err := p.screeningRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if updatedProduct.Screening, err = p.screen(ctx, rules, updatedProduct); err != nil {
+		return nil, err
+	}
+
+	// Writing against the version that was read also catches edits that land
+	// between the ownership check and the update
+	updatedProduct.Version, err = p.repo.UpdateProduct(ctx, updatedProduct, newProductEvent("product_updated", updatedProduct))
+	if err != nil {
+		return nil, err
+	}
+
+	if updatedProduct.Price != product.Price {
+		p.recordPrices(ctx, PriceReasonUpdated, now, updatedProduct)
+	}
 
 	return &updatedProduct, nil
 }
 
+func validateUpdateMask(paths []string) error {
+	for _, path := range paths {
+		if !slices.Contains(updatableFields, path) {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidUpdateMask, path)
+		}
+	}
+	return nil
+}
+
+// DeleteProduct archives the product. Archived products are hidden from
+// search and listings but can still be fetched by ID, so past orders keep
+// resolving them until they are purged.
 func (p productService) DeleteProduct(ctx context.Context, productId string, accountId string) error {
 	product, err := p.repo.GetProductById(ctx, productId)
 	if err != nil {
@@ -166,25 +385,21 @@ func (p productService) DeleteProduct(ctx context.Context, productId string, acc
 	}
 
 	if product.AccountID != accountId {
-		return errors.New("unauthorized")
+		return ErrUnauthorized
 	}
 
-	go func() {
-
-		err = p.SendMessageToRecommender(Event{
-			Type: "product_deleted",
-			Data: EventData{
-				ID: &product.ID,
-			},
-		}, "product_events")
-		if err != nil {
-			log.Printf("Error sending message to recommender: %v", err)
-		}
-	}()
-
-	if err = p.repo.DeleteProduct(ctx, productId); err != nil {
-		return err
+	if product.ArchivedAt != nil {
+		return nil
 	}
 
-	return nil
+	archivedAt := time.Now().UTC()
+	product.ArchivedAt = &archivedAt
+	deleted := Event{
+		Type: "product_deleted",
+		Data: EventData{
+			ID: &product.ID,
+		},
+	}
+	_, err = p.repo.UpdateProduct(ctx, *product, deleted)
+	return err
 }
@@ -0,0 +1,147 @@
+package product
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	suggestTimeout     = 150 * time.Millisecond
+	defaultSuggestSize = 5
+	maxSuggestSize     = 20
+)
+
+type ProductSuggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Suggestions struct {
+	Products   []ProductSuggestion `json:"products"`
+	Categories []string            `json:"categories"`
+}
+
+func (p productService) SuggestProducts(ctx context.Context, prefix string, size int) (*Suggestions, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return &Suggestions{}, nil
+	}
+
+	if size <= 0 {
+		size = defaultSuggestSize
+	}
+	size = min(size, maxSuggestSize)
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+
+	return p.repo.SuggestProducts(ctx, prefix, size)
+}
+
+func (r *elasticRepository) SuggestProducts(ctx context.Context, prefix string, size int) (*Suggestions, error) {
+	nameQuery := map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query": prefix,
+			"type":  "bool_prefix",
+			"fields": []string{
+				"name.suggest",
+				"name.suggest._2gram",
+				"name.suggest._3gram",
+			},
+		},
+	}
+	categoryQuery := map[string]interface{}{
+		"multi_match": map[string]interface{}{
+			"query": prefix,
+			"type":  "bool_prefix",
+			"fields": []string{
+				"category.suggest",
+				"category.suggest._2gram",
+				"category.suggest._3gram",
+			},
+		},
+	}
+
+	searchQuery := map[string]interface{}{
+		"query":            onlyPublished(nameQuery),
+		"size":             size,
+		"_source":          []string{"name"},
+		"track_total_hits": false,
+		"aggs": map[string]interface{}{
+			"categories": map[string]interface{}{
+				"filter": onlyPublished(categoryQuery),
+				"aggs": map[string]interface{}{
+					"names": map[string]interface{}{
+						"terms": map[string]interface{}{
+							"field": "category",
+							"size":  size,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	queryBytes, err := json.Marshal(searchQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.client.Search(
+		r.client.Search.WithIndex(catalogAlias),
+		r.client.Search.WithBody(bytes.NewReader(queryBytes)),
+		r.client.Search.WithTimeout(suggestTimeout),
+		r.client.Search.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("suggest request failed: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []struct {
+				ID     string `json:"_id"`
+				Source struct {
+					Name string `json:"name"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations struct {
+			Categories struct {
+				Names struct {
+					Buckets []struct {
+						Key string `json:"key"`
+					} `json:"buckets"`
+				} `json:"names"`
+			} `json:"categories"`
+		} `json:"aggregations"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	suggestions := &Suggestions{
+		Products:   []ProductSuggestion{},
+		Categories: []string{},
+	}
+	for _, hit := range result.Hits.Hits {
+		suggestions.Products = append(suggestions.Products, ProductSuggestion{
+			ID:   hit.ID,
+			Name: hit.Source.Name,
+		})
+	}
+	for _, bucket := range result.Aggregations.Categories.Names.Buckets {
+		suggestions.Categories = append(suggestions.Categories, bucket.Key)
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,495 @@
+package product
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	DefaultWishlistName = "Saved for later"
+
+	maxWishlistNameLength = 100
+	maxWishlists          = 20
+	maxWishlistItems      = 500
+)
+
+var (
+	ErrInvalidWishlistName = errors.New("wishlist name must be between 1 and 100 characters")
+	ErrWishlistExists      = errors.New("account already has a wishlist with that name")
+	ErrDefaultWishlist     = errors.New("the default wishlist cannot be deleted")
+	ErrTooManyWishlists    = errors.New("account has too many wishlists")
+	ErrWishlistFull        = errors.New("wishlist is full")
+	ErrNoWishlists         = errors.New("wishlists are only kept by the Postgres repository")
+)
+
+type WishlistItem struct {
+	ProductID string    `json:"productId"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
+// Wishlist is a named list of products saved by an account. Anyone with the
+// share token of a public list can read it.
+type Wishlist struct {
+	ID         string         `json:"id"`
+	AccountID  string         `json:"accountId"`
+	Name       string         `json:"name"`
+	Default    bool           `json:"default"`
+	ShareToken string         `json:"shareToken,omitempty"`
+	Items      []WishlistItem `json:"items"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+func (w Wishlist) Public() bool {
+	return w.ShareToken != ""
+}
+
+// WishlistRepository stores wishlists. Lists are read with their items,
+// most recently added first.
+type WishlistRepository interface {
+	// DefaultWishlist returns the default list of the account, creating it
+	// on first use
+	DefaultWishlist(ctx context.Context, accountId string) (*Wishlist, error)
+	ListWishlists(ctx context.Context, accountId string) ([]Wishlist, error)
+	GetWishlist(ctx context.Context, id string) (*Wishlist, error)
+	GetWishlistByShareToken(ctx context.Context, token string) (*Wishlist, error)
+	// CreateWishlist fails with ErrTooManyWishlists once the account has
+	// limit lists
+	CreateWishlist(ctx context.Context, w Wishlist, limit int) error
+	// UpdateWishlist stores the name and share token of the list
+	UpdateWishlist(ctx context.Context, w Wishlist) error
+	DeleteWishlist(ctx context.Context, id string) error
+	// AddWishlistItem adds a product unless the list already has it, and
+	// fails with ErrWishlistFull once the list has limit items
+	AddWishlistItem(ctx context.Context, wishlistId, productId string, limit int) error
+	RemoveWishlistItem(ctx context.Context, wishlistId, productId string) error
+	// MoveWishlistItem moves a product between lists, keeping when it was
+	// added
+	MoveWishlistItem(ctx context.Context, fromId, toId, productId string, limit int) error
+}
+
+func (p productService) wishlistRepository() (WishlistRepository, error) {
+	if p.wishlists == nil {
+		return nil, ErrNoWishlists
+	}
+	return p.wishlists, nil
+}
+
+// ListWishlists returns the lists of an account, starting with its default
+// list. With publicOnly, as when another account views them, only shared
+// lists are returned and the default list is not created.
+func (p productService) ListWishlists(ctx context.Context, accountId string, publicOnly bool) ([]Wishlist, error) {
+	repo, err := p.wishlistRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	if !publicOnly {
+		if _, err := repo.DefaultWishlist(ctx, accountId); err != nil {
+			return nil, err
+		}
+	}
+
+	lists, err := repo.ListWishlists(ctx, accountId)
+	if err != nil {
+		return nil, err
+	}
+	if !publicOnly {
+		return lists, nil
+	}
+
+	public := []Wishlist{}
+	for _, w := range lists {
+		if w.Public() {
+			public = append(public, w)
+		}
+	}
+	return public, nil
+}
+
+// GetSharedWishlist returns the public list with the share token
+func (p productService) GetSharedWishlist(ctx context.Context, token string) (*Wishlist, error) {
+	repo, err := p.wishlistRepository()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	return repo.GetWishlistByShareToken(ctx, token)
+}
+
+func (p productService) CreateWishlist(ctx context.Context, accountId, name string, public bool) (*Wishlist, error) {
+	repo, err := p.wishlistRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = validateWishlistName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	w := Wishlist{
+		ID:        uuid.New().String(),
+		AccountID: accountId,
+		Name:      name,
+		Items:     []WishlistItem{},
+		CreatedAt: time.Now().UTC(),
+	}
+	if public {
+		if w.ShareToken, err = newShareToken(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := repo.CreateWishlist(ctx, w, maxWishlists); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// UpdateWishlist renames a list and shares or unshares it. Sharing a list
+// again gives it a new share token, so old links stop working once a list
+// has been made private.
+func (p productService) UpdateWishlist(ctx context.Context, id, accountId string, name *string, public *bool) (*Wishlist, error) {
+	repo, w, err := p.ownWishlist(ctx, id, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != nil {
+		if w.Name, err = validateWishlistName(*name); err != nil {
+			return nil, err
+		}
+	}
+	if public != nil && *public != w.Public() {
+		w.ShareToken = ""
+		if *public {
+			if w.ShareToken, err = newShareToken(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := repo.UpdateWishlist(ctx, *w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (p productService) DeleteWishlist(ctx context.Context, id, accountId string) error {
+	repo, w, err := p.ownWishlist(ctx, id, accountId)
+	if err != nil {
+		return err
+	}
+	if w.Default {
+		return ErrDefaultWishlist
+	}
+	return repo.DeleteWishlist(ctx, id)
+}
+
+// AddToWishlist saves a product to a list of the account, or to its default
+// list when wishlistId is empty, and tells the recommender about it
+func (p productService) AddToWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error) {
+	repo, err := p.wishlistRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	var w *Wishlist
+	if wishlistId == "" {
+		w, err = repo.DefaultWishlist(ctx, accountId)
+	} else {
+		_, w, err = p.ownWishlist(ctx, wishlistId, accountId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := p.repo.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if !product.IsPublished(time.Now()) {
+		return nil, ErrNotPublished
+	}
+
+	if err := repo.AddWishlistItem(ctx, w.ID, productId, maxWishlistItems); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		err := p.SendMessageToRecommender(Event{
+			Type: "wishlist_added",
+			Data: EventData{
+				ID:     &productId,
+				UserID: &accountId,
+			},
+		}, InteractionEventsTopic)
+		if err != nil {
+			log.Printf("Error sending message to recommender: %v", err)
+		}
+	}()
+
+	return repo.GetWishlist(ctx, w.ID)
+}
+
+func (p productService) RemoveFromWishlist(ctx context.Context, accountId, wishlistId, productId string) (*Wishlist, error) {
+	repo, w, err := p.ownWishlist(ctx, wishlistId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.RemoveWishlistItem(ctx, w.ID, productId); err != nil {
+		return nil, err
+	}
+	return repo.GetWishlist(ctx, w.ID)
+}
+
+// MoveWishlistItem moves a product from one list of the account to another
+// and returns the list it was moved to
+func (p productService) MoveWishlistItem(ctx context.Context, accountId, productId, fromId, toId string) (*Wishlist, error) {
+	repo, from, err := p.ownWishlist(ctx, fromId, accountId)
+	if err != nil {
+		return nil, err
+	}
+	_, to, err := p.ownWishlist(ctx, toId, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.ID != to.ID {
+		if err := repo.MoveWishlistItem(ctx, from.ID, to.ID, productId, maxWishlistItems); err != nil {
+			return nil, err
+		}
+	}
+	return repo.GetWishlist(ctx, to.ID)
+}
+
+// ownWishlist reads a list of accountId. Lists of other accounts are
+// reported as missing.
+func (p productService) ownWishlist(ctx context.Context, id, accountId string) (WishlistRepository, *Wishlist, error) {
+	repo, err := p.wishlistRepository()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	w, err := repo.GetWishlist(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if w.AccountID != accountId {
+		return nil, nil, ErrNotFound
+	}
+	return repo, w, nil
+}
+
+func validateWishlistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxWishlistNameLength {
+		return "", ErrInvalidWishlistName
+	}
+	return name, nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (r *postgresRepository) DefaultWishlist(ctx context.Context, accountId string) (*Wishlist, error) {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO wishlists (id, account_id, name, is_default)
+		VALUES ($1, $2, $3, TRUE)
+		ON CONFLICT (account_id) WHERE is_default DO NOTHING
+	`, uuid.New().String(), accountId, DefaultWishlistName)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getWishlist(ctx, `account_id = $1 AND is_default`, accountId)
+}
+
+func (r *postgresRepository) GetWishlist(ctx context.Context, id string) (*Wishlist, error) {
+	return r.getWishlist(ctx, `id = $1`, id)
+}
+
+func (r *postgresRepository) GetWishlistByShareToken(ctx context.Context, token string) (*Wishlist, error) {
+	return r.getWishlist(ctx, `share_token = $1`, token)
+}
+
+func (r *postgresRepository) getWishlist(ctx context.Context, condition string, args ...interface{}) (*Wishlist, error) {
+	lists, err := r.queryWishlists(ctx, condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, ErrNotFound
+	}
+	return &lists[0], nil
+}
+
+func (r *postgresRepository) ListWishlists(ctx context.Context, accountId string) ([]Wishlist, error) {
+	return r.queryWishlists(ctx, `account_id = $1`, accountId)
+}
+
+// queryWishlists reads the lists matching condition with their items, the
+// default list first and the others by name
+func (r *postgresRepository) queryWishlists(ctx context.Context, condition string, args ...interface{}) ([]Wishlist, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT w.id, w.account_id, w.name, w.is_default, COALESCE(w.share_token, ''), w.created_at,
+			COALESCE(array_agg(i.product_id ORDER BY i.added_at DESC) FILTER (WHERE i.product_id IS NOT NULL), '{}'),
+			COALESCE(array_agg(i.added_at ORDER BY i.added_at DESC) FILTER (WHERE i.product_id IS NOT NULL), '{}')
+		FROM wishlists w
+		LEFT JOIN wishlist_items i ON i.wishlist_id = w.id
+		WHERE `+condition+`
+		GROUP BY w.id
+		ORDER BY w.is_default DESC, lower(w.name)
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Wishlist, error) {
+		var w Wishlist
+		var productIds []string
+		var addedAt []time.Time
+		if err := row.Scan(&w.ID, &w.AccountID, &w.Name, &w.Default, &w.ShareToken, &w.CreatedAt, &productIds, &addedAt); err != nil {
+			return w, err
+		}
+		w.Items = []WishlistItem{}
+		for i, id := range productIds {
+			w.Items = append(w.Items, WishlistItem{ProductID: id, AddedAt: addedAt[i]})
+		}
+		return w, nil
+	})
+}
+
+func (r *postgresRepository) CreateWishlist(ctx context.Context, w Wishlist, limit int) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Serialize list creation per account so the limit holds
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, w.AccountID); err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM wishlists WHERE account_id = $1`, w.AccountID).Scan(&count); err != nil {
+			return err
+		}
+		if count >= limit {
+			return ErrTooManyWishlists
+		}
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO wishlists (id, account_id, name, share_token, created_at)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		`, w.ID, w.AccountID, w.Name, w.ShareToken, w.CreatedAt)
+		return wishlistWriteError(err)
+	})
+}
+
+func (r *postgresRepository) UpdateWishlist(ctx context.Context, w Wishlist) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE wishlists SET
+			name = $2,
+			share_token = NULLIF($3, ''),
+			updated_at = NOW()
+		WHERE id = $1
+	`, w.ID, w.Name, w.ShareToken)
+	if err != nil {
+		return wishlistWriteError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteWishlist(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM wishlists WHERE id = $1`, id)
+	return err
+}
+
+func (r *postgresRepository) AddWishlistItem(ctx context.Context, wishlistId, productId string, limit int) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return addWishlistItem(ctx, tx, wishlistId, productId, nil, limit)
+	})
+}
+
+func (r *postgresRepository) RemoveWishlistItem(ctx context.Context, wishlistId, productId string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $2`, wishlistId, productId)
+	return err
+}
+
+func (r *postgresRepository) MoveWishlistItem(ctx context.Context, fromId, toId, productId string, limit int) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var addedAt time.Time
+		err := tx.QueryRow(ctx, `
+			DELETE FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $2
+			RETURNING added_at
+		`, fromId, productId).Scan(&addedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		return addWishlistItem(ctx, tx, toId, productId, &addedAt, limit)
+	})
+}
+
+// addWishlistItem adds a product to a list locked for the rest of tx. A
+// product already on the list keeps its place.
+func addWishlistItem(ctx context.Context, tx pgx.Tx, wishlistId, productId string, addedAt *time.Time, limit int) error {
+	var count int
+	err := tx.QueryRow(ctx, `
+		SELECT (SELECT COUNT(*) FROM wishlist_items WHERE wishlist_id = w.id)
+		FROM wishlists w WHERE w.id = $1
+		FOR UPDATE
+	`, wishlistId).Scan(&count)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM wishlist_items WHERE wishlist_id = $1 AND product_id = $2)
+	`, wishlistId, productId).Scan(&exists)
+	if err != nil || exists {
+		return err
+	}
+	if count >= limit {
+		return ErrWishlistFull
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO wishlist_items (wishlist_id, product_id, added_at)
+		VALUES ($1, $2, COALESCE($3, NOW()))
+	`, wishlistId, productId, addedAt)
+	return err
+}
+
+// wishlistWriteError reports a clash with another list of the account as
+// ErrWishlistExists
+func wishlistWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	// 23505: unique_violation
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrWishlistExists
+	}
+	return err
+}
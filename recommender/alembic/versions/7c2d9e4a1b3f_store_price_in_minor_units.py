@@ -0,0 +1,36 @@
+"""Store product prices in minor units with a currency
+
+Revision ID: 7c2d9e4a1b3f
+Revises: 41f6eba61502
+Create Date: 2026-10-19 10:12:31.402918
+
+"""
+from typing import Sequence, Union
+
+from alembic import op
+import sqlalchemy as sa
+
+
+# revision identifiers, used by Alembic.
+revision: str = '7c2d9e4a1b3f'
+down_revision: Union[str, None] = '41f6eba61502'
+branch_labels: Union[str, Sequence[str], None] = None
+depends_on: Union[str, Sequence[str], None] = None
+
+
+def upgrade() -> None:
+    # Existing prices are decimal USD amounts
+    op.add_column('products', sa.Column('price_amount', sa.BigInteger(), nullable=True))
+    op.add_column('products', sa.Column('currency', sa.String(length=3), nullable=False, server_default='USD'))
+    op.execute('UPDATE products SET price_amount = ROUND(price * 100)')
+    op.alter_column('products', 'price_amount', nullable=False)
+    op.alter_column('products', 'currency', server_default=None)
+    op.drop_column('products', 'price')
+
+
+def downgrade() -> None:
+    op.add_column('products', sa.Column('price', sa.Float(), nullable=True))
+    op.execute('UPDATE products SET price = price_amount / 100.0')
+    op.alter_column('products', 'price', nullable=False)
+    op.drop_column('products', 'currency')
+    op.drop_column('products', 'price_amount')
@@ -8,7 +8,7 @@ used by the recommendation engine.
 from datetime import datetime
 from typing import List
 
-from sqlalchemy import String, Float, Integer, DateTime, func, ForeignKey
+from sqlalchemy import String, Float, Integer, BigInteger, DateTime, func, ForeignKey
 from sqlalchemy.ext.declarative import declarative_base
 from sqlalchemy.orm import Mapped, mapped_column, relationship
 
@@ -27,7 +27,8 @@ class Product(Base):
     id: Mapped[str] = mapped_column(String, primary_key=True)
     name: Mapped[str] = mapped_column(String, nullable=False)
     description: Mapped[str] = mapped_column(String, nullable=False)
-    price: Mapped[float] = mapped_column(Float, nullable=False)
+    price_amount: Mapped[int] = mapped_column(BigInteger, nullable=False)  # Minor units, e.g. cents
+    currency: Mapped[str] = mapped_column(String(3), nullable=False)  # ISO 4217 code
     account_id: Mapped[str] = mapped_column(String, nullable=False)  # Changed to String for consistency
     created_at: Mapped[datetime] = mapped_column(DateTime, default=func.now())
     updated_at: Mapped[datetime] = mapped_column(DateTime, default=func.now(), onupdate=func.now())
@@ -40,7 +41,7 @@ class Product(Base):
     )
 
     def __repr__(self) -> str:
-        return f"<Product(id='{self.id}', name='{self.name}', price={self.price})>"
+        return f"<Product(id='{self.id}', name='{self.name}', price={self.price_amount} {self.currency})>"
 
 
 class Interaction(Base):
@@ -20,7 +20,10 @@ def _fetch_grpc_products(product_ids):
             id=product.id,
             name=product.name,
             description=product.description,
-            price=product.price,
+            price=recommender_pb2.Money(
+                amount=product.price_amount,
+                currency=product.currency,
+            ),
         )
         for product in products
     ]
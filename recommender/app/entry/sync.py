@@ -38,14 +38,16 @@ def sync_products():
                 if product:
                     product.name = product_data["name"]
                     product.description = product_data["description"]
-                    product.price = product_data["price"]
+                    product.price_amount = product_data["price"]["amount"]
+                    product.currency = product_data["price"]["currency"]
                     product.account_id = product_data["accountID"]
                 else:
                     product = Product(
                         id=product_data["product_id"],
                         name=product_data["name"],
                         description=product_data["description"],
-                        price=product_data["price"],
+                        price_amount=product_data["price"]["amount"],
+                        currency=product_data["price"]["currency"],
                         account_id=product_data["accountID"]
                     )
                     session.add(product)
@@ -70,7 +72,10 @@ def fetch_product_from_graphql(product_id):
                     id
                     name
                     description
-                    price
+                    price {{
+                        amount
+                        currency
+                    }}
                     accountID
                 }}
             }}
@@ -117,7 +122,7 @@ def process_interactions():
         logger.info(f"Received interaction event: {event}")
         
         # Only process actual user interaction events
-        if event["type"] not in ["purchase", "view", "add_to_cart"]:
+        if event["type"] not in ["purchase", "view", "add_to_cart", "wishlist_added"]:
             logger.info(f"Skipping non-interaction event: {event['type']}")
             continue
             
@@ -138,7 +143,8 @@ def process_interactions():
                         id=product_data["id"],
                         name=product_data["name"],
                         description=product_data["description"],
-                        price=product_data["price"],
+                        price_amount=product_data["price"]["amount"],
+                        currency=product_data["price"]["currency"],
                         account_id=product_data["account_id"]
                     )
                     session.add(product)
@@ -4,6 +4,12 @@ from app.db.session import get_db_session
 import pandas as pd
 from surprise import SVD, Dataset, Reader
 
+# Implicit rating of each interaction type, anything else counts as 1.0
+INTERACTION_RATINGS = {
+    "purchase": 3.0,
+    "wishlist_added": 2.0,
+}
+
 def fetch_interactions() -> pd.DataFrame:
     with get_db_session() as session:
         interactions = session.query(Interaction).all()
@@ -12,7 +18,7 @@ def fetch_interactions() -> pd.DataFrame:
                 "user_id": interaction.user_id,
                 "product_id": interaction.product_id,
                 "interaction_type": interaction.interaction_type,
-                "rating": 3.0 if interaction.interaction_type == "purchase" else 1.0,
+                "rating": INTERACTION_RATINGS.get(interaction.interaction_type, 1.0),
                 "timestamp": interaction.timestamp,
             }
             for interaction in interactions
@@ -22,6 +22,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Money is an amount in the minor unit (e.g. cents) of an ISO 4217 currency
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_recommender_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_recommender_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_recommender_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type ProductInteraction struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -33,7 +86,7 @@ type ProductInteraction struct {
 
 func (x *ProductInteraction) Reset() {
 	*x = ProductInteraction{}
-	mi := &file_recommender_proto_msgTypes[0]
+	mi := &file_recommender_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -45,7 +98,7 @@ func (x *ProductInteraction) String() string {
 func (*ProductInteraction) ProtoMessage() {}
 
 func (x *ProductInteraction) ProtoReflect() protoreflect.Message {
-	mi := &file_recommender_proto_msgTypes[0]
+	mi := &file_recommender_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,7 +111,7 @@ func (x *ProductInteraction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductInteraction.ProtoReflect.Descriptor instead.
 func (*ProductInteraction) Descriptor() ([]byte, []int) {
-	return file_recommender_proto_rawDescGZIP(), []int{0}
+	return file_recommender_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *ProductInteraction) GetUserId() string {
@@ -93,7 +146,7 @@ type RecommendationRequestForUserId struct {
 
 func (x *RecommendationRequestForUserId) Reset() {
 	*x = RecommendationRequestForUserId{}
-	mi := &file_recommender_proto_msgTypes[1]
+	mi := &file_recommender_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -105,7 +158,7 @@ func (x *RecommendationRequestForUserId) String() string {
 func (*RecommendationRequestForUserId) ProtoMessage() {}
 
 func (x *RecommendationRequestForUserId) ProtoReflect() protoreflect.Message {
-	mi := &file_recommender_proto_msgTypes[1]
+	mi := &file_recommender_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -118,7 +171,7 @@ func (x *RecommendationRequestForUserId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecommendationRequestForUserId.ProtoReflect.Descriptor instead.
 func (*RecommendationRequestForUserId) Descriptor() ([]byte, []int) {
-	return file_recommender_proto_rawDescGZIP(), []int{1}
+	return file_recommender_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RecommendationRequestForUserId) GetUserId() string {
@@ -153,7 +206,7 @@ type RecommendationRequestOnViews struct {
 
 func (x *RecommendationRequestOnViews) Reset() {
 	*x = RecommendationRequestOnViews{}
-	mi := &file_recommender_proto_msgTypes[2]
+	mi := &file_recommender_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -165,7 +218,7 @@ func (x *RecommendationRequestOnViews) String() string {
 func (*RecommendationRequestOnViews) ProtoMessage() {}
 
 func (x *RecommendationRequestOnViews) ProtoReflect() protoreflect.Message {
-	mi := &file_recommender_proto_msgTypes[2]
+	mi := &file_recommender_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -178,7 +231,7 @@ func (x *RecommendationRequestOnViews) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecommendationRequestOnViews.ProtoReflect.Descriptor instead.
 func (*RecommendationRequestOnViews) Descriptor() ([]byte, []int) {
-	return file_recommender_proto_rawDescGZIP(), []int{2}
+	return file_recommender_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *RecommendationRequestOnViews) GetIds() []string {
@@ -207,14 +260,14 @@ type ProductReplica struct {
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Price         *Money                 `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProductReplica) Reset() {
 	*x = ProductReplica{}
-	mi := &file_recommender_proto_msgTypes[3]
+	mi := &file_recommender_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -226,7 +279,7 @@ func (x *ProductReplica) String() string {
 func (*ProductReplica) ProtoMessage() {}
 
 func (x *ProductReplica) ProtoReflect() protoreflect.Message {
-	mi := &file_recommender_proto_msgTypes[3]
+	mi := &file_recommender_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -239,7 +292,7 @@ func (x *ProductReplica) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProductReplica.ProtoReflect.Descriptor instead.
 func (*ProductReplica) Descriptor() ([]byte, []int) {
-	return file_recommender_proto_rawDescGZIP(), []int{3}
+	return file_recommender_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ProductReplica) GetId() string {
@@ -263,11 +316,11 @@ func (x *ProductReplica) GetDescription() string {
 	return ""
 }
 
-func (x *ProductReplica) GetPrice() float64 {
+func (x *ProductReplica) GetPrice() *Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
 type RecommendationResponse struct {
@@ -279,7 +332,7 @@ type RecommendationResponse struct {
 
 func (x *RecommendationResponse) Reset() {
 	*x = RecommendationResponse{}
-	mi := &file_recommender_proto_msgTypes[4]
+	mi := &file_recommender_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +344,7 @@ func (x *RecommendationResponse) String() string {
 func (*RecommendationResponse) ProtoMessage() {}
 
 func (x *RecommendationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_recommender_proto_msgTypes[4]
+	mi := &file_recommender_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +357,7 @@ func (x *RecommendationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecommendationResponse.ProtoReflect.Descriptor instead.
 func (*RecommendationResponse) Descriptor() ([]byte, []int) {
-	return file_recommender_proto_rawDescGZIP(), []int{4}
+	return file_recommender_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *RecommendationResponse) GetRecommendedProducts() []*ProductReplica {
@@ -318,7 +371,10 @@ var File_recommender_proto protoreflect.FileDescriptor
 
 const file_recommender_proto_rawDesc = "" +
 	"\n" +
-	"\x11recommender.proto\x12\x02pb\x1a\x1bgoogle/protobuf/empty.proto\"w\n" +
+	"\x11recommender.proto\x12\x02pb\x1a\x1bgoogle/protobuf/empty.proto\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"w\n" +
 	"\x12ProductInteraction\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
@@ -331,12 +387,12 @@ const file_recommender_proto_rawDesc = "" +
 	"\x1cRecommendationRequestOnViews\x12\x10\n" +
 	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x12\n" +
 	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
-	"\x04take\x18\x03 \x01(\x04R\x04take\"l\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\"}\n" +
 	"\x0eProductReplica\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\"_\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\x05price\x18\x05 \x01(\v2\t.pb.MoneyR\x05priceJ\x04\b\x04\x10\x05\"_\n" +
 	"\x16RecommendationResponse\x12E\n" +
 	"\x14recommended_products\x18\x01 \x03(\v2\x12.pb.ProductReplicaR\x13recommendedProducts2\x93\x02\n" +
 	"\x12RecommenderService\x12]\n" +
@@ -356,28 +412,30 @@ func file_recommender_proto_rawDescGZIP() []byte {
 	return file_recommender_proto_rawDescData
 }
 
-var file_recommender_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_recommender_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_recommender_proto_goTypes = []any{
-	(*ProductInteraction)(nil),             // 0: pb.ProductInteraction
-	(*RecommendationRequestForUserId)(nil), // 1: pb.RecommendationRequestForUserId
-	(*RecommendationRequestOnViews)(nil),   // 2: pb.RecommendationRequestOnViews
-	(*ProductReplica)(nil),                 // 3: pb.ProductReplica
-	(*RecommendationResponse)(nil),         // 4: pb.RecommendationResponse
-	(*emptypb.Empty)(nil),                  // 5: google.protobuf.Empty
+	(*Money)(nil),                          // 0: pb.Money
+	(*ProductInteraction)(nil),             // 1: pb.ProductInteraction
+	(*RecommendationRequestForUserId)(nil), // 2: pb.RecommendationRequestForUserId
+	(*RecommendationRequestOnViews)(nil),   // 3: pb.RecommendationRequestOnViews
+	(*ProductReplica)(nil),                 // 4: pb.ProductReplica
+	(*RecommendationResponse)(nil),         // 5: pb.RecommendationResponse
+	(*emptypb.Empty)(nil),                  // 6: google.protobuf.Empty
 }
 var file_recommender_proto_depIdxs = []int32{
-	3, // 0: pb.RecommendationResponse.recommended_products:type_name -> pb.ProductReplica
-	1, // 1: pb.RecommenderService.GetRecommendationsForUserId:input_type -> pb.RecommendationRequestForUserId
-	2, // 2: pb.RecommenderService.GetRecommendationsOnViews:input_type -> pb.RecommendationRequestOnViews
-	0, // 3: pb.RecommenderService.RecordInteraction:input_type -> pb.ProductInteraction
-	4, // 4: pb.RecommenderService.GetRecommendationsForUserId:output_type -> pb.RecommendationResponse
-	4, // 5: pb.RecommenderService.GetRecommendationsOnViews:output_type -> pb.RecommendationResponse
-	5, // 6: pb.RecommenderService.RecordInteraction:output_type -> google.protobuf.Empty
-	4, // [4:7] is the sub-list for method output_type
-	1, // [1:4] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 0: pb.ProductReplica.price:type_name -> pb.Money
+	4, // 1: pb.RecommendationResponse.recommended_products:type_name -> pb.ProductReplica
+	2, // 2: pb.RecommenderService.GetRecommendationsForUserId:input_type -> pb.RecommendationRequestForUserId
+	3, // 3: pb.RecommenderService.GetRecommendationsOnViews:input_type -> pb.RecommendationRequestOnViews
+	1, // 4: pb.RecommenderService.RecordInteraction:input_type -> pb.ProductInteraction
+	5, // 5: pb.RecommenderService.GetRecommendationsForUserId:output_type -> pb.RecommendationResponse
+	5, // 6: pb.RecommenderService.GetRecommendationsOnViews:output_type -> pb.RecommendationResponse
+	6, // 7: pb.RecommenderService.RecordInteraction:output_type -> google.protobuf.Empty
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_recommender_proto_init() }
@@ -391,7 +449,7 @@ func file_recommender_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_recommender_proto_rawDesc), len(file_recommender_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
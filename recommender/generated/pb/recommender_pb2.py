@@ -25,7 +25,7 @@ _sym_db = _symbol_database.Default()
 from google.protobuf import empty_pb2 as google_dot_protobuf_dot_empty__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x11recommender.proto\x12\x02pb\x1a\x1bgoogle/protobuf/empty.proto\"S\n\x12ProductInteraction\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\x12\n\nproduct_id\x18\x02 \x01(\t\x12\x18\n\x10interaction_type\x18\x03 \x01(\t\"M\n\x1eRecommendationRequestForUserId\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\x0c\n\x04skip\x18\x02 \x01(\x04\x12\x0c\n\x04take\x18\x03 \x01(\x04\"G\n\x1cRecommendationRequestOnViews\x12\x0b\n\x03ids\x18\x01 \x03(\t\x12\x0c\n\x04skip\x18\x02 \x01(\x04\x12\x0c\n\x04take\x18\x03 \x01(\x04\"N\n\x0eProductReplica\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x03 \x01(\t\x12\r\n\x05price\x18\x04 \x01(\x01\"J\n\x16RecommendationResponse\x12\x30\n\x14recommended_products\x18\x01 \x03(\x0b\x32\x12.pb.ProductReplica2\x93\x02\n\x12RecommenderService\x12]\n\x1bGetRecommendationsForUserId\x12\".pb.RecommendationRequestForUserId\x1a\x1a.pb.RecommendationResponse\x12Y\n\x19GetRecommendationsOnViews\x12 .pb.RecommendationRequestOnViews\x1a\x1a.pb.RecommendationResponse\x12\x43\n\x11RecordInteraction\x12\x16.pb.ProductInteraction\x1a\x16.google.protobuf.EmptyB\x06Z\x04./pbb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x11recommender.proto\x12\x02pb\x1a\x1bgoogle/protobuf/empty.proto\")\n\x05Money\x12\x0e\n\x06\x61mount\x18\x01 \x01(\x03\x12\x10\n\x08\x63urrency\x18\x02 \x01(\t\"S\n\x12ProductInteraction\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\x12\n\nproduct_id\x18\x02 \x01(\t\x12\x18\n\x10interaction_type\x18\x03 \x01(\t\"M\n\x1eRecommendationRequestForUserId\x12\x0f\n\x07user_id\x18\x01 \x01(\t\x12\x0c\n\x04skip\x18\x02 \x01(\x04\x12\x0c\n\x04take\x18\x03 \x01(\x04\"G\n\x1cRecommendationRequestOnViews\x12\x0b\n\x03ids\x18\x01 \x03(\t\x12\x0c\n\x04skip\x18\x02 \x01(\x04\x12\x0c\n\x04take\x18\x03 \x01(\x04\"_\n\x0eProductReplica\x12\n\n\x02id\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x13\n\x0b\x64\x65scription\x18\x03 \x01(\t\x12\x18\n\x05price\x18\x05 \x01(\x0b\x32\t.pb.MoneyJ\x04\x08\x04\x10\x05\"J\n\x16RecommendationResponse\x12\x30\n\x14recommended_products\x18\x01 \x03(\x0b\x32\x12.pb.ProductReplica2\x93\x02\n\x12RecommenderService\x12]\n\x1bGetRecommendationsForUserId\x12\".pb.RecommendationRequestForUserId\x1a\x1a.pb.RecommendationResponse\x12Y\n\x19GetRecommendationsOnViews\x12 .pb.RecommendationRequestOnViews\x1a\x1a.pb.RecommendationResponse\x12\x43\n\x11RecordInteraction\x12\x16.pb.ProductInteraction\x1a\x16.google.protobuf.EmptyB\x06Z\x04./pbb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -33,16 +33,18 @@ _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'recommender_pb2', _globals)
 if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z\004./pb'
-  _globals['_PRODUCTINTERACTION']._serialized_start=54
-  _globals['_PRODUCTINTERACTION']._serialized_end=137
-  _globals['_RECOMMENDATIONREQUESTFORUSERID']._serialized_start=139
-  _globals['_RECOMMENDATIONREQUESTFORUSERID']._serialized_end=216
-  _globals['_RECOMMENDATIONREQUESTONVIEWS']._serialized_start=218
-  _globals['_RECOMMENDATIONREQUESTONVIEWS']._serialized_end=289
-  _globals['_PRODUCTREPLICA']._serialized_start=291
-  _globals['_PRODUCTREPLICA']._serialized_end=369
-  _globals['_RECOMMENDATIONRESPONSE']._serialized_start=371
-  _globals['_RECOMMENDATIONRESPONSE']._serialized_end=445
-  _globals['_RECOMMENDERSERVICE']._serialized_start=448
-  _globals['_RECOMMENDERSERVICE']._serialized_end=723
+  _globals['_MONEY']._serialized_start=54
+  _globals['_MONEY']._serialized_end=95
+  _globals['_PRODUCTINTERACTION']._serialized_start=97
+  _globals['_PRODUCTINTERACTION']._serialized_end=180
+  _globals['_RECOMMENDATIONREQUESTFORUSERID']._serialized_start=182
+  _globals['_RECOMMENDATIONREQUESTFORUSERID']._serialized_end=259
+  _globals['_RECOMMENDATIONREQUESTONVIEWS']._serialized_start=261
+  _globals['_RECOMMENDATIONREQUESTONVIEWS']._serialized_end=332
+  _globals['_PRODUCTREPLICA']._serialized_start=334
+  _globals['_PRODUCTREPLICA']._serialized_end=429
+  _globals['_RECOMMENDATIONRESPONSE']._serialized_start=431
+  _globals['_RECOMMENDATIONRESPONSE']._serialized_end=505
+  _globals['_RECOMMENDERSERVICE']._serialized_start=508
+  _globals['_RECOMMENDERSERVICE']._serialized_end=783
 # @@protoc_insertion_point(module_scope)
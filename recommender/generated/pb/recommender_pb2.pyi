@@ -7,6 +7,14 @@ from typing import ClassVar as _ClassVar, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class Money(_message.Message):
+    __slots__ = ("amount", "currency")
+    AMOUNT_FIELD_NUMBER: _ClassVar[int]
+    CURRENCY_FIELD_NUMBER: _ClassVar[int]
+    amount: int
+    currency: str
+    def __init__(self, amount: _Optional[int] = ..., currency: _Optional[str] = ...) -> None: ...
+
 class ProductInteraction(_message.Message):
     __slots__ = ("user_id", "product_id", "interaction_type")
     USER_ID_FIELD_NUMBER: _ClassVar[int]
@@ -46,8 +54,8 @@ class ProductReplica(_message.Message):
     id: str
     name: str
     description: str
-    price: float
-    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., description: _Optional[str] = ..., price: _Optional[float] = ...) -> None: ...
+    price: Money
+    def __init__(self, id: _Optional[str] = ..., name: _Optional[str] = ..., description: _Optional[str] = ..., price: _Optional[_Union[Money, _Mapping]] = ...) -> None: ...
 
 class RecommendationResponse(_message.Message):
     __slots__ = ("recommended_products",)